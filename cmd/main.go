@@ -12,6 +12,6 @@ func main() {
 	}
 	defer singleinstance.Release()
 
-	apps := logic.FindApplications()
-	ui.RunLauncher(apps)
+	logic.WarmIconCache()
+	ui.RunLauncher()
 }
@@ -0,0 +1,441 @@
+// Package calc evalúa las expresiones matemáticas que se escriben en el
+// buscador, sin ejecutar nada: solo números, operadores, paréntesis,
+// porcentajes, funciones y constantes conocidas.
+//
+// Operadores, de menor a mayor precedencia: + -, * / y % (módulo), ^ (o **,
+// asociativo a la derecha) y el % final de porcentaje. "100+10%" suma el 10 %
+// de 100. Se aceptan literales 0x, 0b y 0o, y un sufijo "in hex|bin|oct|dec"
+// para elegir la base del resultado.
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrNotExpression indica que el texto se puede evaluar pero no tiene nada
+// que calcular (un número o una constante sueltos), así que no merece fila.
+var ErrNotExpression = errors.New("no es una expresión")
+
+// Result es el valor de una expresión y la base en la que mostrarlo: 2, 8,
+// 16 o, con cualquier otro valor (incluido el cero), decimal.
+type Result struct {
+	Value float64
+	Base  int
+}
+
+var constants = map[string]float64{
+	"pi":  math.Pi,
+	"π":   math.Pi,
+	"tau": 2 * math.Pi,
+	"τ":   2 * math.Pi,
+	"e":   math.E,
+}
+
+var functions = map[string]func(float64) float64{
+	"sqrt":  math.Sqrt,
+	"cbrt":  math.Cbrt,
+	"abs":   math.Abs,
+	"sin":   math.Sin,
+	"cos":   math.Cos,
+	"tan":   math.Tan,
+	"asin":  math.Asin,
+	"acos":  math.Acos,
+	"atan":  math.Atan,
+	"ln":    math.Log,
+	"log":   math.Log10,
+	"log2":  math.Log2,
+	"exp":   math.Exp,
+	"floor": math.Floor,
+	"ceil":  math.Ceil,
+	"round": math.Round,
+}
+
+var bases = map[string]int{
+	"hex": 16, "hexadecimal": 16,
+	"bin": 2, "binary": 2,
+	"oct": 8, "octal": 8,
+	"dec": 10, "decimal": 10,
+}
+
+// Eval calcula input. Devuelve ErrNotExpression si no hay operaciones.
+func Eval(input string) (Result, error) {
+	expr, base := splitBase(input)
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return Result{}, err
+	}
+	if len(tokens) == 0 {
+		return Result{}, ErrNotExpression
+	}
+
+	p := &parser{tokens: tokens}
+	value, _, err := p.expr()
+	if err != nil {
+		return Result{}, err
+	}
+	if p.pos < len(p.tokens) {
+		return Result{}, fmt.Errorf("sobra %q", p.tokens[p.pos].text)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Result{}, errors.New("resultado no definido")
+	}
+	if p.ops == 0 && base == 0 && !p.based {
+		return Result{}, ErrNotExpression
+	}
+	if base == 0 {
+		base = 10
+	}
+	return Result{Value: value, Base: base}, nil
+}
+
+// splitBase separa el sufijo "in hex", "to bin"... de la expresión.
+func splitBase(input string) (string, int) {
+	lower := strings.ToLower(input)
+	for _, sep := range []string{" in ", " to ", " as "} {
+		i := strings.LastIndex(lower, sep)
+		if i < 0 {
+			continue
+		}
+		if base, ok := bases[strings.TrimSpace(lower[i+len(sep):])]; ok {
+			return input[:i], base
+		}
+	}
+	return input, 0
+}
+
+// basePrefixes son las bases distintas de la decimal que sabe mostrar String.
+var basePrefixes = map[int]string{16: "0x", 2: "0b", 8: "0o"}
+
+// String formatea el valor en su base; los valores no enteros se muestran
+// siempre en decimal.
+func (r Result) String() string {
+	prefix, ok := basePrefixes[r.Base]
+	if ok && r.Value == math.Trunc(r.Value) && math.Abs(r.Value) < 1<<63 {
+		n := int64(r.Value)
+		sign := ""
+		if n < 0 {
+			sign, n = "-", -n
+		}
+		return sign + prefix + strings.ToUpper(strconv.FormatInt(n, r.Base))
+	}
+	return FormatNumber(r.Value)
+}
+
+// FormatNumber redondea a 12 cifras significativas, para que 0.1+0.2 sea
+// 0.3, y usa notación científica solo para valores muy grandes o pequeños.
+func FormatNumber(v float64) string {
+	v, _ = strconv.ParseFloat(strconv.FormatFloat(v, 'g', 12, 64), 64)
+	if v == 0 {
+		return "0"
+	}
+	if abs := math.Abs(v); abs >= 1e15 || abs < 1e-9 {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+type tokenKind int
+
+const (
+	tokNumber tokenKind = iota
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value float64
+	based bool // literal 0x, 0b u 0o
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r >= '0' && r <= '9' || r == '.':
+			tok, n, err := lexNumber(runes[i:])
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			i += n
+		case unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: strings.ToLower(string(runes[start:i]))})
+		case r == '*' && i+1 < len(runes) && runes[i+1] == '*':
+			tokens = append(tokens, token{kind: tokOp, text: "^"})
+			i += 2
+		case strings.ContainsRune("+-*/%^()", r):
+			tokens = append(tokens, token{kind: tokOp, text: string(r)})
+			i++
+		case r == '×':
+			tokens = append(tokens, token{kind: tokOp, text: "*"})
+			i++
+		case r == '÷':
+			tokens = append(tokens, token{kind: tokOp, text: "/"})
+			i++
+		default:
+			return nil, fmt.Errorf("carácter no válido %q", r)
+		}
+	}
+	return tokens, nil
+}
+
+func lexNumber(r []rune) (token, int, error) {
+	if len(r) > 2 && r[0] == '0' {
+		base := map[rune]int{'x': 16, 'X': 16, 'b': 2, 'B': 2, 'o': 8, 'O': 8}[r[1]]
+		if base != 0 {
+			n := 2
+			for n < len(r) && isDigitIn(r[n], base) {
+				n++
+			}
+			if n == 2 {
+				return token{}, 0, fmt.Errorf("número incompleto %q", string(r[:2]))
+			}
+			v, err := strconv.ParseUint(string(r[2:n]), base, 64)
+			if err != nil {
+				return token{}, 0, err
+			}
+			return token{kind: tokNumber, text: string(r[:n]), value: float64(v), based: true}, n, nil
+		}
+	}
+
+	n := 0
+	for n < len(r) && (r[n] >= '0' && r[n] <= '9' || r[n] == '.') {
+		n++
+	}
+	// Exponente solo si le sigue un dígito: "2e" es 2 por la constante e.
+	if n < len(r) && (r[n] == 'e' || r[n] == 'E') {
+		m := n + 1
+		if m < len(r) && (r[m] == '+' || r[m] == '-') {
+			m++
+		}
+		if m < len(r) && r[m] >= '0' && r[m] <= '9' {
+			for m < len(r) && r[m] >= '0' && r[m] <= '9' {
+				m++
+			}
+			n = m
+		}
+	}
+	v, err := strconv.ParseFloat(string(r[:n]), 64)
+	if err != nil {
+		return token{}, 0, fmt.Errorf("número no válido %q", string(r[:n]))
+	}
+	return token{kind: tokNumber, text: string(r[:n]), value: v}, n, nil
+}
+
+func isDigitIn(r rune, base int) bool {
+	d, err := strconv.ParseUint(string(r), 16, 8)
+	return err == nil && int(d) < base
+}
+
+// parser es un descenso recursivo. Cada nivel devuelve además si su valor
+// es un porcentaje suelto ("10%"), para que la suma lo aplique sobre el
+// operando izquierdo.
+type parser struct {
+	tokens []token
+	pos    int
+	ops    int  // operaciones y funciones vistas
+	based  bool // algún literal no decimal
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *parser) peekOp(ops ...string) (string, bool) {
+	tok, ok := p.peek()
+	if !ok || tok.kind != tokOp {
+		return "", false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// startsOperand dice si el siguiente token puede empezar un operando, para
+// distinguir "10 % 3" (módulo) de "10%" (porcentaje). Un signo detrás se
+// toma como suma o resta: "100 + 10% - 5".
+func (p *parser) startsOperand() bool {
+	tok, ok := p.peek()
+	if !ok {
+		return false
+	}
+	return tok.kind != tokOp || tok.text == "("
+}
+
+func (p *parser) expr() (float64, bool, error) {
+	left, pct, err := p.term()
+	if err != nil {
+		return 0, false, err
+	}
+	for {
+		op, ok := p.peekOp("+", "-")
+		if !ok {
+			return left, pct, nil
+		}
+		p.pos++
+		p.ops++
+		right, rightPct, err := p.term()
+		if err != nil {
+			return 0, false, err
+		}
+		if rightPct {
+			right *= left
+		}
+		if op == "+" {
+			left += right
+		} else {
+			left -= right
+		}
+		pct = false
+	}
+}
+
+func (p *parser) term() (float64, bool, error) {
+	left, pct, err := p.unary()
+	if err != nil {
+		return 0, false, err
+	}
+	for {
+		op, ok := p.peekOp("*", "/", "%")
+		if ok {
+			p.pos++
+		} else if tok, more := p.peek(); more && (tok.kind == tokIdent || tok.text == "(") {
+			// Multiplicación implícita: "2pi", "3(1+2)".
+			op = "*"
+		} else {
+			return left, pct, nil
+		}
+		p.ops++
+		right, _, err := p.unary()
+		if err != nil {
+			return 0, false, err
+		}
+		switch op {
+		case "*":
+			left *= right
+		case "/":
+			if right == 0 {
+				return 0, false, errors.New("división por cero")
+			}
+			left /= right
+		case "%":
+			if right == 0 {
+				return 0, false, errors.New("división por cero")
+			}
+			left = math.Mod(left, right)
+		}
+		pct = false
+	}
+}
+
+func (p *parser) unary() (float64, bool, error) {
+	if op, ok := p.peekOp("-", "+"); ok {
+		p.pos++
+		v, pct, err := p.unary()
+		if op == "-" {
+			v = -v
+		}
+		return v, pct, err
+	}
+	return p.power()
+}
+
+func (p *parser) power() (float64, bool, error) {
+	base, pct, err := p.postfix()
+	if err != nil {
+		return 0, false, err
+	}
+	if _, ok := p.peekOp("^"); !ok {
+		return base, pct, nil
+	}
+	p.pos++
+	p.ops++
+	exp, _, err := p.unary()
+	if err != nil {
+		return 0, false, err
+	}
+	return math.Pow(base, exp), false, nil
+}
+
+func (p *parser) postfix() (float64, bool, error) {
+	v, err := p.primary()
+	if err != nil {
+		return 0, false, err
+	}
+	pct := false
+	for {
+		if _, ok := p.peekOp("%"); !ok {
+			return v, pct, nil
+		}
+		p.pos++
+		if p.startsOperand() {
+			p.pos-- // es un módulo; lo resuelve term
+			return v, pct, nil
+		}
+		p.ops++
+		v /= 100
+		pct = true
+	}
+}
+
+func (p *parser) primary() (float64, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return 0, errors.New("expresión incompleta")
+	}
+	p.pos++
+	switch {
+	case tok.kind == tokNumber:
+		p.based = p.based || tok.based
+		return tok.value, nil
+	case tok.kind == tokIdent:
+		if v, ok := constants[tok.text]; ok {
+			return v, nil
+		}
+		fn, ok := functions[tok.text]
+		if !ok {
+			return 0, fmt.Errorf("nombre desconocido %q", tok.text)
+		}
+		if _, ok := p.peekOp("("); !ok {
+			return 0, fmt.Errorf("falta '(' tras %s", tok.text)
+		}
+		p.ops++
+		arg, err := p.primary()
+		if err != nil {
+			return 0, err
+		}
+		return fn(arg), nil
+	case tok.text == "(":
+		v, _, err := p.expr()
+		if err != nil {
+			return 0, err
+		}
+		if _, ok := p.peekOp(")"); !ok {
+			return 0, errors.New("falta ')'")
+		}
+		p.pos++
+		return v, nil
+	}
+	return 0, fmt.Errorf("sobra %q", tok.text)
+}
@@ -0,0 +1,75 @@
+package calc
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"(12*3)/4+2^8", "265"},
+		{"1 + 2 * 3", "7"},
+		{"2^3^2", "512"},
+		{"-2^2", "-4"},
+		{"2**10", "1024"},
+		{"0.1+0.2", "0.3"},
+		{"10 % 3", "1"},
+		{"50%", "0.5"},
+		{"200 * 15%", "30"},
+		{"100 + 10%", "110"},
+		{"100 - 10% + 5", "95"},
+		{"sqrt(16) + abs(-2)", "6"},
+		{"log(1000)", "3"},
+		{"ln(e)", "1"},
+		{"2pi", "6.28318530718"},
+		{"3(1+2)", "9"},
+		{"0xff + 0b1", "256"},
+		{"0xff", "255"},
+		{"255 in hex", "0xFF"},
+		{"10 to bin", "0b1010"},
+		{"0x10 * 2 in oct", "0o40"},
+		{"-255 in hex", "-0xFF"},
+		{"1.5 in hex", "1.5"},
+		{"6 × 7 ÷ 2", "21"},
+		{"1e3 / 4", "250"},
+	}
+	for _, tt := range tests {
+		got, err := Eval(tt.input)
+		if err != nil {
+			t.Fatalf("Eval(%q): %v", tt.input, err)
+		}
+		if got.String() != tt.want {
+			t.Fatalf("Eval(%q) = %s, se esperaba %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestResultString(t *testing.T) {
+	tests := []struct {
+		result Result
+		want   string
+	}{
+		{Result{Value: 42}, "42"},
+		{Result{Value: 255, Base: 16}, "0xFF"},
+		{Result{Value: 5, Base: 3}, "5"},
+	}
+	for _, tt := range tests {
+		if got := tt.result.String(); got != tt.want {
+			t.Fatalf("%+v.String() = %s, se esperaba %s", tt.result, got, tt.want)
+		}
+	}
+}
+
+func TestEvalRejects(t *testing.T) {
+	for _, input := range []string{"", "42", "pi", "-3", "firefox", "7zip", "1/0", "sqrt(-1)", "(1+2", "2+", "sqrt 4", "os.exit(1)"} {
+		if got, err := Eval(input); err == nil {
+			t.Fatalf("Eval(%q) = %s, se esperaba error", input, got)
+		}
+	}
+	if _, err := Eval("42"); !errors.Is(err, ErrNotExpression) {
+		t.Fatalf("Eval(42): err = %v", err)
+	}
+}
@@ -20,6 +20,19 @@ type Config struct {
 	AutoStart    bool       `json:"auto_start"`
 	StartHidden  bool       `json:"start_hidden"`
 	ThemeName    string     `json:"theme_name"`
+	IconTheme    string     `json:"icon_theme"` // tema de iconos (Linux); vacío = el del escritorio
+	// DisabledPlugins son los nombres de fichero de los plugins apagados.
+	DisabledPlugins []string `json:"disabled_plugins"`
+	// ShellInTerminal abre las órdenes ">" en un terminal en lugar de
+	// ejecutarlas en segundo plano; Terminal es el emulador y sus opciones
+	// (por ejemplo "kitty" o "gnome-terminal --"), vacío = detectarlo.
+	ShellInTerminal bool   `json:"shell_in_terminal"`
+	Terminal        string `json:"terminal"`
+	// FileRoots son las carpetas que indexa la búsqueda de ficheros ("/"),
+	// vacío = la carpeta personal; FileIgnore son los nombres que se
+	// saltan, con comodines, vacío = los predeterminados.
+	FileRoots  []string `json:"file_roots"`
+	FileIgnore []string `json:"file_ignore"`
 }
 
 func DefaultConfig() Config {
@@ -74,6 +87,26 @@ func (c *Config) Normalize() {
 	c.ToggleHotkey = normalizeKeyBinding(c.ToggleHotkey, defaults.ToggleHotkey)
 	c.QuitHotkey = normalizeKeyBinding(c.QuitHotkey, defaults.QuitHotkey)
 	c.ThemeName = normalizeThemeName(c.ThemeName, defaults.ThemeName)
+	c.IconTheme = strings.TrimSpace(c.IconTheme)
+	c.DisabledPlugins = normalizeNames(c.DisabledPlugins)
+	c.Terminal = strings.TrimSpace(c.Terminal)
+	c.FileRoots = normalizeNames(c.FileRoots)
+	c.FileIgnore = normalizeNames(c.FileIgnore)
+}
+
+// normalizeNames quita vacíos y repetidos conservando el orden.
+func normalizeNames(names []string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		out = append(out, name)
+	}
+	return out
 }
 
 func normalizeKeyBinding(binding, fallback KeyBinding) KeyBinding {
@@ -0,0 +1,296 @@
+// Package fuzzy puntúa qué tan bien encaja una consulta con un texto, para
+// ordenar los resultados del lanzador en cada pulsación.
+//
+// Las coincidencias se clasifican por niveles, de mejor a peor: exacta,
+// prefijo, inicio de palabra, acrónimo ("vsc" → "Visual Studio Code") y
+// subsecuencia. Dentro de cada nivel se penalizan los huecos y la distancia
+// al inicio, de modo que un nivel superior siempre gana a uno inferior.
+package fuzzy
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+type Tier int
+
+const (
+	TierNone Tier = iota
+	TierSubsequence
+	TierAcronym
+	TierWordStart
+	TierPrefix
+	TierExact
+)
+
+// Base de puntuación de cada nivel; las penalizaciones nunca superan el
+// margen entre dos niveles consecutivos.
+var tierBase = map[Tier]int{
+	TierSubsequence: 200,
+	TierAcronym:     400,
+	TierWordStart:   600,
+	TierPrefix:      800,
+	TierExact:       1000,
+}
+
+const maxPenalty = 199
+
+// Base es la puntuación de una coincidencia perfecta del nivel, útil para
+// comparar solo por nivel.
+func (t Tier) Base() int {
+	return tierBase[t]
+}
+
+type Match struct {
+	Tier  Tier
+	Score int
+}
+
+// Range es un tramo [Start, End) de runas del texto que coincide con la
+// consulta.
+type Range struct {
+	Start, End int
+}
+
+// Matcher puntúa una misma consulta contra muchos textos reutilizando sus
+// buffers, para no reservar memoria por cada aplicación en cada pulsación.
+// No es seguro para uso concurrente.
+type Matcher struct {
+	query []rune
+	t, lt []rune
+}
+
+func NewMatcher(query string) *Matcher {
+	return &Matcher{query: lowerRunes(query)}
+}
+
+// Score compara query con target sin distinguir mayúsculas. Una consulta
+// vacía coincide con todo con puntuación 0.
+func Score(query, target string) (Match, bool) {
+	return NewMatcher(query).Score(target)
+}
+
+func (m *Matcher) Score(target string) (Match, bool) {
+	q := m.query
+	if len(q) == 0 {
+		return Match{}, true
+	}
+	m.t, m.lt = m.t[:0], m.lt[:0]
+	for _, r := range target {
+		m.t = append(m.t, r)
+		m.lt = append(m.lt, toLower(r))
+	}
+	t, lt := m.t, m.lt
+	// Todos los niveles implican subsecuencia: si no la hay, no hay nada
+	// más que comprobar.
+	gaps, first, ok := subsequenceMatch(lt, q)
+	if !ok {
+		return Match{}, false
+	}
+
+	switch {
+	case runesEqual(lt, q):
+		return newMatch(TierExact, 0), true
+	case hasPrefixAt(lt, q, 0):
+		return newMatch(TierPrefix, len(lt)-len(q)), true
+	}
+
+	if start, ok := wordStartMatch(t, lt, q); ok {
+		return newMatch(TierWordStart, start), true
+	}
+	if skipped, ok := acronymMatch(t, lt, q); ok {
+		return newMatch(TierAcronym, skipped*5), true
+	}
+	return newMatch(TierSubsequence, gaps*3+first), true
+}
+
+// Ranges devuelve los tramos de target que explican su coincidencia, para
+// resaltarlos en la interfaz. Se calcula aparte de Score porque solo hace
+// falta para las filas visibles. Devuelve nil si no hay coincidencia.
+func (m *Matcher) Ranges(target string) []Range {
+	match, ok := m.Score(target)
+	if !ok || len(m.query) == 0 {
+		return nil
+	}
+	t, lt, q := m.t, m.lt, m.query
+	switch match.Tier {
+	case TierExact, TierPrefix:
+		return []Range{{0, len(q)}}
+	case TierWordStart:
+		start, _ := wordStartMatch(t, lt, q)
+		return []Range{{start, start + len(q)}}
+	case TierAcronym:
+		return mergePositions(acronymPositions(t, lt, q))
+	default:
+		return mergePositions(subsequencePositions(lt, q))
+	}
+}
+
+func newMatch(tier Tier, penalty int) Match {
+	if penalty > maxPenalty {
+		penalty = maxPenalty
+	}
+	return Match{Tier: tier, Score: tierBase[tier] - penalty}
+}
+
+// wordStartMatch busca la consulta completa empezando en un inicio de palabra.
+func wordStartMatch(t, lt, q []rune) (int, bool) {
+	for i := 1; i+len(q) <= len(lt); i++ {
+		if isWordStart(t, i) && hasPrefixAt(lt, q, i) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// acronymMatch casa cada letra de la consulta con la inicial de una palabra,
+// en orden; devuelve cuántas palabras se saltaron.
+func acronymMatch(t, lt, q []rune) (int, bool) {
+	if len(q) < 2 {
+		return 0, false
+	}
+	qi, skipped := 0, 0
+	for i := range lt {
+		if !isWordStart(t, i) {
+			continue
+		}
+		if qi < len(q) && lt[i] == q[qi] {
+			qi++
+			continue
+		}
+		if qi < len(q) {
+			skipped++
+		}
+	}
+	return skipped, qi == len(q)
+}
+
+// subsequenceMatch casa las letras de la consulta en orden, de forma voraz,
+// y devuelve el total de caracteres saltados entre ellas y la posición de la
+// primera.
+func subsequenceMatch(lt, q []rune) (int, int, bool) {
+	qi, gaps, first, last := 0, 0, -1, -1
+	for i := 0; i < len(lt) && qi < len(q); i++ {
+		if lt[i] != q[qi] {
+			continue
+		}
+		if first < 0 {
+			first = i
+		} else {
+			gaps += i - last - 1
+		}
+		last = i
+		qi++
+	}
+	return gaps, first, qi == len(q)
+}
+
+func acronymPositions(t, lt, q []rune) []int {
+	positions := make([]int, 0, len(q))
+	for i := range lt {
+		if len(positions) == len(q) {
+			break
+		}
+		if isWordStart(t, i) && lt[i] == q[len(positions)] {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+func subsequencePositions(lt, q []rune) []int {
+	positions := make([]int, 0, len(q))
+	for i := 0; i < len(lt) && len(positions) < len(q); i++ {
+		if lt[i] == q[len(positions)] {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+// mergePositions une posiciones consecutivas en tramos.
+func mergePositions(positions []int) []Range {
+	var ranges []Range
+	for _, p := range positions {
+		if n := len(ranges); n > 0 && ranges[n-1].End == p {
+			ranges[n-1].End++
+			continue
+		}
+		ranges = append(ranges, Range{p, p + 1})
+	}
+	return ranges
+}
+
+func isWordStart(t []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, cur := t[i-1], t[i]
+	if isSeparator(prev) {
+		return !isSeparator(cur)
+	}
+	if isLower(prev) && isUpper(cur) {
+		return true
+	}
+	// Fin de una sigla en mayúsculas: la "C" de "VSCode".
+	return isUpper(prev) && isUpper(cur) && i+1 < len(t) && isLower(t[i+1])
+}
+
+// Atajos ASCII: la mayoría de nombres de aplicación lo son y las funciones
+// de unicode son notablemente más lentas en el bucle de cada pulsación.
+
+func toLower(r rune) rune {
+	if r < utf8.RuneSelf {
+		if 'A' <= r && r <= 'Z' {
+			return r + ('a' - 'A')
+		}
+		return r
+	}
+	return unicode.ToLower(r)
+}
+
+func isLower(r rune) bool {
+	if r < utf8.RuneSelf {
+		return 'a' <= r && r <= 'z'
+	}
+	return unicode.IsLower(r)
+}
+
+func isUpper(r rune) bool {
+	if r < utf8.RuneSelf {
+		return 'A' <= r && r <= 'Z'
+	}
+	return unicode.IsUpper(r)
+}
+
+func isSeparator(r rune) bool {
+	switch r {
+	case ' ', '-', '_', '.', '/', '(', ')', ':', '+', '&':
+		return true
+	}
+	return r >= utf8.RuneSelf && unicode.IsSpace(r)
+}
+
+func lowerRunes(s string) []rune {
+	out := make([]rune, 0, utf8.RuneCountInString(s))
+	for _, r := range s {
+		out = append(out, toLower(r))
+	}
+	return out
+}
+
+func hasPrefixAt(s, prefix []rune, at int) bool {
+	if at+len(prefix) > len(s) {
+		return false
+	}
+	for i, r := range prefix {
+		if s[at+i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+func runesEqual(a, b []rune) bool {
+	return len(a) == len(b) && hasPrefixAt(a, b, 0)
+}
@@ -0,0 +1,131 @@
+package fuzzy
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestScoreTiers(t *testing.T) {
+	tests := []struct {
+		query  string
+		target string
+		want   Tier
+	}{
+		{"firefox", "Firefox", TierExact},
+		{"fire", "Firefox", TierPrefix},
+		{"studio", "Visual Studio Code", TierWordStart},
+		{"code", "VSCode", TierWordStart},
+		{"vsc", "Visual Studio Code", TierAcronym},
+		{"lo", "LibreOffice Writer", TierAcronym},
+		{"frfx", "Firefox", TierSubsequence},
+		{"zz", "Firefox", TierNone},
+		{"", "Firefox", TierNone},
+	}
+
+	for _, tt := range tests {
+		m, ok := Score(tt.query, tt.target)
+		if tt.want == TierNone && tt.query != "" {
+			if ok {
+				t.Fatalf("Score(%q, %q) matched with %+v", tt.query, tt.target, m)
+			}
+			continue
+		}
+		if !ok || m.Tier != tt.want {
+			t.Fatalf("Score(%q, %q) = %+v, %v; want tier %d", tt.query, tt.target, m, ok, tt.want)
+		}
+	}
+}
+
+func TestScoreOrdering(t *testing.T) {
+	// El orden de entrada no coincide con el esperado: los empates los
+	// decide el nombre, no la posición.
+	targets := []string{"Kate", "Terminal", "Steam", "GNOME Text Editor", "Telegram"}
+	sort.Slice(targets, func(i, j int) bool {
+		mi, _ := Score("te", targets[i])
+		mj, _ := Score("te", targets[j])
+		if mi.Score != mj.Score {
+			return mi.Score > mj.Score
+		}
+		return targets[i] < targets[j]
+	})
+
+	want := []string{"Telegram", "Terminal", "GNOME Text Editor", "Steam", "Kate"}
+	for i := range want {
+		if targets[i] != want[i] {
+			t.Fatalf("order = %v, want %v", targets, want)
+		}
+	}
+}
+
+func TestScoreGapPenalty(t *testing.T) {
+	tight, _ := Score("gmp", "gimp")
+	loose, _ := Score("gmp", "gnome-system-monitor-app")
+	if tight.Score <= loose.Score {
+		t.Fatalf("tight %d should beat loose %d", tight.Score, loose.Score)
+	}
+}
+
+func TestRanges(t *testing.T) {
+	tests := []struct {
+		query  string
+		target string
+		want   string
+	}{
+		{"fire", "Firefox", "[{0 4}]"},
+		{"studio", "Visual Studio Code", "[{7 13}]"},
+		{"code", "VSCode", "[{2 6}]"},
+		{"vsc", "Visual Studio Code", "[{0 1} {7 8} {14 15}]"},
+		{"frfx", "Firefox", "[{0 1} {2 3} {4 5} {6 7}]"},
+		{"fifo", "Firefox", "[{0 2} {4 6}]"},
+		{"ñu", "Ñu Ñandú", "[{0 2}]"},
+		{"zz", "Firefox", "[]"},
+	}
+
+	for _, tt := range tests {
+		got := fmt.Sprint(NewMatcher(tt.query).Ranges(tt.target))
+		if got != tt.want {
+			t.Fatalf("Ranges(%q, %q) = %s, want %s", tt.query, tt.target, got, tt.want)
+		}
+	}
+}
+
+var benchWords = []string{
+	"Visual", "Studio", "Code", "Firefox", "Telegram", "Terminal", "Office", "Writer",
+	"Calc", "Impress", "GIMP", "Inkscape", "Blender", "Steam", "Discord", "Slack",
+	"Files", "Settings", "Monitor", "System", "Editor", "Player", "Viewer", "Manager",
+}
+
+// benchTargets genera 10k nombres de aplicación deterministas de 2-4 palabras.
+func benchTargets() []string {
+	targets := make([]string, 10000)
+	for i := range targets {
+		n := 2 + i%3
+		name := ""
+		for w := 0; w < n; w++ {
+			if w > 0 {
+				name += " "
+			}
+			name += benchWords[(i*7+w*13)%len(benchWords)]
+		}
+		targets[i] = fmt.Sprintf("%s %d", name, i)
+	}
+	return targets
+}
+
+func benchmarkScore(b *testing.B, query string) {
+	targets := benchTargets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		matcher := NewMatcher(query)
+		for _, target := range targets {
+			matcher.Score(target)
+		}
+	}
+}
+
+func BenchmarkScore10kPrefix(b *testing.B)      { benchmarkScore(b, "fire") }
+func BenchmarkScore10kAcronym(b *testing.B)     { benchmarkScore(b, "vsc") }
+func BenchmarkScore10kSubsequence(b *testing.B) { benchmarkScore(b, "tlgrm") }
+func BenchmarkScore10kNoMatch(b *testing.B)     { benchmarkScore(b, "xyzzy") }
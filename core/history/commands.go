@@ -0,0 +1,92 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	commandsFileName = "commands.json"
+	MaxCommands      = 200
+)
+
+// Commands es el historial de órdenes de shell ejecutadas con ">", aparte
+// del de aplicaciones. Una orden repetida se mueve al final en lugar de
+// duplicarse.
+type Commands struct {
+	mu       sync.Mutex
+	path     string
+	commands []string // de la más antigua a la más reciente
+}
+
+// LoadCommands lee el historial de órdenes del directorio de configuración;
+// si no existe devuelve uno vacío.
+func LoadCommands() (*Commands, error) {
+	cfgDir, err := os.UserConfigDir()
+	if err != nil {
+		return &Commands{}, err
+	}
+	return LoadCommandsFile(filepath.Join(cfgDir, appName, commandsFileName))
+}
+
+func LoadCommandsFile(path string) (*Commands, error) {
+	c := &Commands{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(data, &c.commands); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// Add guarda command como la orden más reciente.
+func (c *Commands) Add(command string) error {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	kept := c.commands[:0]
+	for _, existing := range c.commands {
+		if existing != command {
+			kept = append(kept, existing)
+		}
+	}
+	c.commands = append(kept, command)
+	if extra := len(c.commands) - MaxCommands; extra > 0 {
+		c.commands = append([]string(nil), c.commands[extra:]...)
+	}
+	return c.saveLocked()
+}
+
+// List devuelve una copia de las órdenes, de la más antigua a la más
+// reciente.
+func (c *Commands) List() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.commands...)
+}
+
+func (c *Commands) saveLocked() error {
+	if c.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(c.commands)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0o644)
+}
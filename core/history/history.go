@@ -0,0 +1,174 @@
+// Package history guarda los lanzamientos de aplicaciones para ordenar los
+// resultados por "frecency" (frecuencia ponderada por lo reciente que es
+// cada uso).
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	appName    = "GoFinder"
+	fileName   = "history.json"
+	MaxEntries = 1000
+)
+
+type Entry struct {
+	App   string    `json:"app"`
+	Query string    `json:"query"`
+	Time  time.Time `json:"time"`
+}
+
+type History struct {
+	mu      sync.Mutex
+	path    string
+	entries []Entry
+}
+
+// Load lee el historial del directorio de configuración; si no existe
+// devuelve uno vacío.
+func Load() (*History, error) {
+	path, err := historyPath()
+	if err != nil {
+		return &History{}, err
+	}
+	return LoadFile(path)
+}
+
+func LoadFile(path string) (*History, error) {
+	h := &History{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return h, err
+	}
+	if err := json.Unmarshal(data, &h.entries); err != nil {
+		return h, err
+	}
+	return h, nil
+}
+
+// Record añade un lanzamiento y guarda el historial, descartando las
+// entradas más antiguas por encima de MaxEntries.
+func (h *History) Record(app, query string, at time.Time) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, Entry{App: app, Query: query, Time: at})
+	if extra := len(h.entries) - MaxEntries; extra > 0 {
+		h.entries = append([]Entry(nil), h.entries[extra:]...)
+	}
+	return h.saveLocked()
+}
+
+// RenameApps reescribe las entradas cuyo app aparece en renames y guarda el
+// historial solo si cambió algo. Sirve para migrar claves antiguas.
+func (h *History) RenameApps(renames map[string]string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	changed := false
+	for i, entry := range h.entries {
+		if to, ok := renames[entry.App]; ok && to != entry.App {
+			h.entries[i].App = to
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return h.saveLocked()
+}
+
+func (h *History) Clear() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = nil
+	return h.saveLocked()
+}
+
+func (h *History) Entries() []Entry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Entry(nil), h.entries...)
+}
+
+// Frecency puntúa cada app sumando un peso por lanzamiento que decrece con
+// la antigüedad, al estilo de la barra de direcciones de Firefox.
+func (h *History) Frecency(now time.Time) map[string]float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	scores := make(map[string]float64)
+	for _, entry := range h.entries {
+		scores[entry.App] += recencyWeight(now.Sub(entry.Time))
+	}
+	return scores
+}
+
+// Picks cuenta, por app, cuántas veces se eligió tras escribir una consulta
+// que empieza por query: si con "tel" se abrió Telegram, "te" también lo
+// recuerda. Es independiente de la frecency para que una app poco usada
+// pueda ganar su propio prefijo.
+func (h *History) Picks(query string) map[string]int {
+	query = normalizeQuery(query)
+	if query == "" {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	picks := make(map[string]int)
+	for _, entry := range h.entries {
+		if strings.HasPrefix(normalizeQuery(entry.Query), query) {
+			picks[entry.App]++
+		}
+	}
+	return picks
+}
+
+func normalizeQuery(query string) string {
+	return strings.ToLower(strings.TrimSpace(query))
+}
+
+func recencyWeight(age time.Duration) float64 {
+	const day = 24 * time.Hour
+	switch {
+	case age < 4*day:
+		return 100
+	case age < 14*day:
+		return 70
+	case age < 31*day:
+		return 50
+	case age < 90*day:
+		return 30
+	default:
+		return 10
+	}
+}
+
+func (h *History) saveLocked() error {
+	if h.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(h.entries)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(h.path, data, 0o644)
+}
+
+func historyPath() (string, error) {
+	cfgDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cfgDir, appName, fileName), nil
+}
@@ -0,0 +1,134 @@
+package history
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFrecency(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	h, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	old := now.Add(-60 * 24 * time.Hour)
+	for i := 0; i < 3; i++ {
+		if err := h.Record("old", "o", old); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := h.Record("recent", "r", now); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scores := reloaded.Frecency(now)
+	if scores["recent"] != 100 || scores["old"] != 90 {
+		t.Fatalf("unexpected scores: %v", scores)
+	}
+
+	if err := reloaded.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if cleared, _ := LoadFile(path); len(cleared.Entries()) != 0 {
+		t.Fatal("history was not cleared")
+	}
+}
+
+func TestRecordCap(t *testing.T) {
+	h := &History{}
+	for i := 0; i < MaxEntries+10; i++ {
+		_ = h.Record("app", "", time.Now())
+	}
+	if got := len(h.Entries()); got != MaxEntries {
+		t.Fatalf("len = %d, want %d", got, MaxEntries)
+	}
+}
+
+func TestPicks(t *testing.T) {
+	h := &History{}
+	now := time.Now()
+	_ = h.Record("telegram", "te", now)
+	_ = h.Record("telegram", "Tel", now)
+	_ = h.Record("terminal", "ter", now)
+	_ = h.Record("terminal", "", now)
+
+	cases := []struct {
+		query string
+		want  map[string]int
+	}{
+		{"t", map[string]int{"telegram": 2, "terminal": 1}},
+		{"TE", map[string]int{"telegram": 2, "terminal": 1}},
+		{"tel", map[string]int{"telegram": 1}},
+		{"x", map[string]int{}},
+		{"", nil},
+	}
+	for _, c := range cases {
+		got := h.Picks(c.query)
+		if len(got) != len(c.want) {
+			t.Fatalf("Picks(%q) = %v, want %v", c.query, got, c.want)
+		}
+		for app, n := range c.want {
+			if got[app] != n {
+				t.Fatalf("Picks(%q) = %v, want %v", c.query, got, c.want)
+			}
+		}
+	}
+}
+
+func TestRenameApps(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	h, _ := LoadFile(path)
+	now := time.Now()
+	_ = h.Record("firefox.desktop", "fi", now)
+	_ = h.Record("desktop:gimp.desktop", "gi", now)
+	_ = h.Record("gone.desktop", "go", now)
+
+	err := h.RenameApps(map[string]string{
+		"firefox.desktop": "desktop:firefox.desktop",
+		"gimp.desktop":    "desktop:gimp.desktop",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, _ := LoadFile(path)
+	var got []string
+	for _, entry := range reloaded.Entries() {
+		got = append(got, entry.App)
+	}
+	want := []string{"desktop:firefox.desktop", "desktop:gimp.desktop", "gone.desktop"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("apps = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestCommands(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "commands.json")
+	c, err := LoadCommandsFile(path)
+	if err != nil {
+		t.Fatalf("LoadCommandsFile: %v", err)
+	}
+	for _, command := range []string{"ls", "make test", " ls ", ""} {
+		if err := c.Add(command); err != nil {
+			t.Fatalf("Add(%q): %v", command, err)
+		}
+	}
+
+	reloaded, err := LoadCommandsFile(path)
+	if err != nil {
+		t.Fatalf("LoadCommandsFile: %v", err)
+	}
+	if got, want := reloaded.List(), []string{"make test", "ls"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("List() = %q, want %q", got, want)
+	}
+}
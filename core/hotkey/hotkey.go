@@ -1,6 +1,8 @@
 package hotkey
 
 import (
+	"strings"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/widget"
 
@@ -30,6 +32,20 @@ type KeyEventInterceptor struct {
 	OnMenuQuit  func()
 	OnMenuPrefs func()
 	OnMenuAbout func()
+	// OnExpand se llama con Tab, o con Right si el cursor está al final, y
+	// OnCollapse con Tab o Left; devuelven si han consumido la tecla. Tab
+	// prueba primero OnCollapse.
+	OnExpand   func() bool
+	OnCollapse func() bool
+
+	// History, si no es nil y el texto empieza por HistoryPrefix, hace que
+	// Up/Down recorran sus entradas (de la más antigua a la más reciente)
+	// en lugar de llamar a OnKeyUp/OnKeyDown.
+	History       func() []string
+	HistoryPrefix string
+	historyPos    int
+	historyDraft  string // lo que había escrito antes de empezar a recorrer
+	historyShown  string // lo último que puso el historial en el campo
 }
 
 // NewKeyEventInterceptor crea el Entry personalizado para eventos de teclado
@@ -42,20 +58,80 @@ func NewKeyEventInterceptor() *KeyEventInterceptor {
 func (e *KeyEventInterceptor) TypedKey(ev *fyne.KeyEvent) {
 	switch ev.Name {
 	case fyne.KeyDown:
+		if e.recallHistory(false) {
+			return
+		}
 		if e.OnKeyDown != nil {
 			e.OnKeyDown()
 			return
 		}
 	case fyne.KeyUp:
+		if e.recallHistory(true) {
+			return
+		}
 		if e.OnKeyUp != nil {
 			e.OnKeyUp()
 			return
 		}
+	case fyne.KeyTab:
+		// Nunca se escribe un tabulador en la búsqueda.
+		if !e.collapse() && e.OnExpand != nil {
+			e.OnExpand()
+		}
+		return
+	case fyne.KeyRight:
+		if e.CursorColumn == len([]rune(e.Text)) && e.OnExpand != nil && e.OnExpand() {
+			return
+		}
+	case fyne.KeyLeft:
+		if e.collapse() {
+			return
+		}
 	}
 
 	e.Entry.TypedKey(ev)
 }
 
+func (e *KeyEventInterceptor) collapse() bool {
+	return e.OnCollapse != nil && e.OnCollapse()
+}
+
+// AcceptsTab hace que Tab llegue a TypedKey en lugar de mover el foco.
+func (e *KeyEventInterceptor) AcceptsTab() bool {
+	return e.OnExpand != nil || e.OnCollapse != nil
+}
+
+// recallHistory pone en el campo la entrada anterior o siguiente del
+// historial. Al bajar de la más reciente se recupera lo que había escrito.
+func (e *KeyEventInterceptor) recallHistory(older bool) bool {
+	if e.History == nil || e.HistoryPrefix == "" || !strings.HasPrefix(e.Text, e.HistoryPrefix) {
+		return false
+	}
+	entries := e.History()
+	if e.Text != e.historyShown {
+		// Se ha editado el texto: se vuelve a empezar por la más reciente.
+		e.historyPos, e.historyDraft = len(entries), e.Text
+	}
+
+	pos := e.historyPos + 1
+	if older {
+		pos = e.historyPos - 1
+	}
+	if pos < 0 || pos > len(entries) {
+		return true
+	}
+	e.historyPos = pos
+	text := e.historyDraft
+	if pos < len(entries) {
+		text = entries[pos]
+	}
+	e.historyShown = text
+	e.SetText(text)
+	e.CursorColumn = len([]rune(text))
+	e.Refresh()
+	return true
+}
+
 func (e *KeyEventInterceptor) TypedShortcut(shortcut fyne.Shortcut) {
 	if handleMenuShortcut(shortcut, e.OnMenuQuit, e.OnMenuPrefs, e.OnMenuAbout) {
 		return
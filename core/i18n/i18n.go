@@ -23,6 +23,8 @@ const (
 	TrayToggleTooltip    = "tray.toggle.tooltip"
 	TrayMinimizeTitle    = "tray.minimize.title"
 	TrayMinimizeTip      = "tray.minimize.tooltip"
+	TrayRescanTitle      = "tray.rescan.title"
+	TrayRescanTooltip    = "tray.rescan.tooltip"
 	TrayQuitTitle        = "tray.quit.title"
 	TrayQuitTooltip      = "tray.quit.tooltip"
 	AppExitMessage       = "app.exit.message"
@@ -43,6 +45,18 @@ const (
 	SettingsAutoSaved    = "settings.autostart.saved"
 	SettingsHiddenSaved  = "settings.hidden.saved"
 	SettingsHotkeysSaved = "settings.hotkeys.saved"
+	SettingsHistory      = "settings.history"
+	SettingsHistoryClear = "settings.history.clear"
+	SettingsHistoryClean = "settings.history.cleared"
+	SettingsPlugins      = "settings.plugins"
+	SettingsPluginsDesc  = "settings.plugins.description"
+	SettingsPluginsDir   = "settings.plugins.dir"
+	SettingsPluginsEmpty = "settings.plugins.empty"
+	SettingsPluginsSaved = "settings.plugins.saved"
+	SettingsShellTerm    = "settings.shell.terminal"
+	SettingsShellSaved   = "settings.shell.saved"
+	SettingsShellEmu     = "settings.shell.emulator"
+	SettingsShellEmuHint = "settings.shell.emulator.hint"
 	ThemeSystem          = "theme.system"
 	ThemeLight           = "theme.light"
 	ThemeDark            = "theme.dark"
@@ -57,9 +71,24 @@ const (
 	MenuConfig           = "menu.config"
 	MenuHelp             = "menu.help"
 	MenuExit             = "menu.exit"
+	MenuRescan           = "menu.rescan"
 	MenuPreferences      = "menu.preferences"
 	MenuAbout            = "menu.about"
 	DialogClose          = "dialog.close"
+	ActionOpen           = "action.open"
+	ActionCopy           = "action.copy"
+	ActionRun            = "action.run"
+	ActionRunTerminal    = "action.run_terminal"
+	ActionReveal         = "action.reveal"
+	ActionOpenWith       = "action.open_with"
+	ShellHint            = "shell.hint"
+	ShellRun             = "shell.run"
+	ShellExitStatus      = "shell.exit_status"
+	ShellError           = "shell.error"
+	RecentJustNow        = "recent.just_now"
+	RecentMinutes        = "recent.minutes"
+	RecentHours          = "recent.hours"
+	RecentDays           = "recent.days"
 	AboutText            = "about.text"
 )
 
@@ -121,6 +150,32 @@ func NormalizeLanguage(value string) Language {
 	}
 }
 
+// CurrentLocale devuelve el locale POSIX (lang_COUNTRY@MODIFIER, sin
+// codificación) del sistema si coincide con el idioma activo; en otro caso
+// devuelve solo el código del idioma activo.
+func CurrentLocale() string {
+	language := CurrentLanguage()
+	locale := os.Getenv("GOFINDER_LANG")
+	if locale == "" {
+		locale = systemLocale()
+	}
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "-", "_")
+
+	modifier := ""
+	if at := strings.Index(locale, "@"); at >= 0 {
+		locale, modifier = locale[:at], locale[at:]
+	}
+	if dot := strings.Index(locale, "."); dot >= 0 {
+		locale = locale[:dot]
+	}
+
+	lang, _, _ := strings.Cut(locale, "_")
+	if !strings.EqualFold(lang, string(language)) {
+		return string(language)
+	}
+	return locale + modifier
+}
+
 func CurrentLanguage() Language {
 	mu.RLock()
 	defer mu.RUnlock()
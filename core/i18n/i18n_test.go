@@ -28,3 +28,71 @@ func TestTranslationFallback(t *testing.T) {
 		t.Fatalf("missing key = %q", got)
 	}
 }
+
+func TestCurrentLocale(t *testing.T) {
+	tests := map[string]struct {
+		env      string
+		language Language
+		want     string
+	}{
+		"full":     {"es_ES.UTF-8@euro", Spanish, "es_ES@euro"},
+		"windows":  {"ca-ES", Catalan, "ca_ES"},
+		"mismatch": {"fr_FR.UTF-8", English, "en"},
+	}
+	defer SetLanguage(CurrentLanguage())
+
+	for name, tt := range tests {
+		t.Setenv("GOFINDER_LANG", tt.env)
+		SetLanguage(tt.language)
+		if got := CurrentLocale(); got != tt.want {
+			t.Fatalf("%s: CurrentLocale() = %q, want %q", name, got, tt.want)
+		}
+	}
+}
+
+func TestLocalizeNumber(t *testing.T) {
+	tests := []struct {
+		language Language
+		in, want string
+	}{
+		{English, "1234567.25", "1,234,567.25"},
+		{English, "-999", "-999"},
+		{Spanish, "1234.5", "1234,5"},
+		{Spanish, "12345.5", "12.345,5"},
+		{Catalan, "1234.5", "1.234,5"},
+		{Spanish, "1.5e+20", "1,5e+20"},
+	}
+	defer SetLanguage(CurrentLanguage())
+	for _, tt := range tests {
+		SetLanguage(tt.language)
+		if got := LocalizeNumber(tt.in); got != tt.want {
+			t.Fatalf("%s: LocalizeNumber(%q) = %q, want %q", tt.language, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		language Language
+		in       string
+		want     float64
+	}{
+		{English, "1,500", 1500},
+		{English, "1,5", 1.5},
+		{English, "1,234,567.25", 1234567.25},
+		{English, "2.5", 2.5},
+		{Spanish, "1.500", 1500},
+		{Spanish, "1.5", 1.5},
+		{Spanish, "12.345,5", 12345.5},
+		{Spanish, "1,5", 1.5},
+		{Catalan, "−40", -40},
+	}
+	defer SetLanguage(CurrentLanguage())
+	for _, tt := range tests {
+		SetLanguage(tt.language)
+		got, err := ParseNumber(tt.in)
+		if err != nil || got != tt.want {
+			t.Fatalf("%s: ParseNumber(%q) = %v, %v; want %v", tt.language, tt.in, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+)
+
+// numberFormat son los separadores de un idioma. minGroup es cuántas cifras
+// debe haber por encima de los millares para agrupar: en español "1234"
+// se escribe sin punto y "12.345" con él.
+type numberFormat struct {
+	decimal  byte
+	group    byte
+	minGroup int
+}
+
+var numberFormats = map[Language]numberFormat{
+	English: {decimal: '.', group: ',', minGroup: 1},
+	Spanish: {decimal: ',', group: '.', minGroup: 2},
+	Catalan: {decimal: ',', group: '.', minGroup: 1},
+}
+
+func currentNumberFormat() numberFormat {
+	if f, ok := numberFormats[CurrentLanguage()]; ok {
+		return f
+	}
+	return numberFormats[English]
+}
+
+// LocalizeNumber aplica los separadores del idioma activo a un número
+// escrito en formato Go ("-1234.5", "1.5e+20").
+func LocalizeNumber(s string) string {
+	f := currentNumberFormat()
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	mantissa, exponent := s, ""
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa, exponent = s[:i], s[i:]
+	}
+	integer, fraction, hasFraction := strings.Cut(mantissa, ".")
+
+	var b strings.Builder
+	b.WriteString(sign)
+	if exponent == "" && len(integer)-3 >= f.minGroup {
+		for i, r := range integer {
+			if i > 0 && (len(integer)-i)%3 == 0 {
+				b.WriteByte(f.group)
+			}
+			b.WriteRune(r)
+		}
+	} else {
+		b.WriteString(integer)
+	}
+	if hasFraction {
+		b.WriteByte(f.decimal)
+		b.WriteString(fraction)
+	}
+	b.WriteString(exponent)
+	return b.String()
+}
+
+// ParseNumber lee un número escrito a mano, con los separadores del idioma
+// activo o con punto decimal. Si solo aparece el separador de millares una
+// vez y no le siguen tres cifras, se toma como decimal: "1,5" es 1.5 en
+// inglés y "1.5" lo es en español.
+func ParseNumber(s string) (float64, error) {
+	s = strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "", "\u2212", "-").Replace(strings.TrimSpace(s))
+	f := currentNumberFormat()
+
+	lastDot, lastComma := strings.LastIndexByte(s, '.'), strings.LastIndexByte(s, ',')
+	decimal := f.decimal
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		// Con los dos, el último es el decimal.
+		decimal = '.'
+		if lastComma > lastDot {
+			decimal = ','
+		}
+	case lastDot >= 0 || lastComma >= 0:
+		sep := byte('.')
+		if lastComma >= 0 {
+			sep = ','
+		}
+		if sep == f.group {
+			i := strings.IndexByte(s, sep)
+			isGroup := i == strings.LastIndexByte(s, sep) && len(s)-i-1 == 3
+			if strings.Count(s, string(sep)) > 1 || isGroup {
+				decimal = f.decimal
+			} else {
+				decimal = sep
+			}
+		}
+	}
+
+	var group byte = ','
+	if decimal == ',' {
+		group = '.'
+	}
+	s = strings.ReplaceAll(s, string(group), "")
+	s = strings.Replace(s, string(decimal), ".", 1)
+	return strconv.ParseFloat(s, 64)
+}
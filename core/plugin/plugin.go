@@ -0,0 +1,519 @@
+// Package plugin ejecuta proveedores de resultados externos: cada ejecutable
+// de la carpeta plugins (junto a la configuración) es un plugin que habla con
+// GoFinder por stdin/stdout, un objeto JSON por línea.
+//
+// Peticiones de GoFinder al plugin:
+//
+//	{"id":1,"type":"info"}
+//	{"id":2,"type":"query","query":"texto"}
+//	{"id":3,"type":"action","result":"r1","action":"open"}
+//
+// Respuestas del plugin, con el id de la petición:
+//
+//	{"id":1,"name":"Notas","prefix":"n ","priority":0,"timeout_ms":300}
+//	{"id":2,"results":[{"id":"r1","title":"Nota","subtitle":"~/notas/a.md","icon":"/ruta/icono.png","score":500,"actions":[{"id":"open","title":"Abrir"}]}]}
+//	{"id":3}
+//
+// timeout_ms no puede pasar de un segundo; sin él se aplica el timeout por
+// defecto de los proveedores.
+//
+// Cualquier respuesta puede llevar "error". Las respuestas pueden llegar en
+// cualquier orden y las que llegan tarde se descartan. El plugin debe
+// terminar cuando se cierre su stdin.
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/adelylria/GoFinder/core/logger"
+	"github.com/adelylria/GoFinder/core/provider"
+)
+
+const (
+	// startTimeout es lo que se espera a la respuesta info al arrancar.
+	startTimeout = 2 * time.Second
+	// actionTimeout es lo que se espera a que el plugin confirme una acción.
+	actionTimeout = 5 * time.Second
+	// maxQueryTimeout acota el timeout_ms del plugin: el gestor espera al
+	// proveedor más lento antes de mostrar nada.
+	maxQueryTimeout = time.Second
+	maxLineSize     = 1 << 20
+)
+
+// restartDelay evita relanzar en bucle un plugin que se cae al arrancar.
+var restartDelay = 5 * time.Second
+
+var errExited = errors.New("el plugin ha terminado")
+
+type request struct {
+	ID     uint64 `json:"id"`
+	Type   string `json:"type"`
+	Query  string `json:"query,omitempty"`
+	Result string `json:"result,omitempty"`
+	Action string `json:"action,omitempty"`
+}
+
+type response struct {
+	ID    uint64 `json:"id"`
+	Error string `json:"error,omitempty"`
+
+	// info
+	Name      string `json:"name"`
+	Prefix    string `json:"prefix"`
+	Priority  int    `json:"priority"`
+	TimeoutMS int    `json:"timeout_ms"`
+
+	// query
+	Results []wireResult `json:"results"`
+}
+
+type wireResult struct {
+	ID       string       `json:"id"`
+	Title    string       `json:"title"`
+	Subtitle string       `json:"subtitle"`
+	Icon     string       `json:"icon"` // ruta a un fichero de imagen
+	Score    int          `json:"score"`
+	Actions  []wireAction `json:"actions"`
+}
+
+type wireAction struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// Dir devuelve la carpeta de la que se cargan los plugins.
+func Dir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "GoFinder", "plugins"), nil
+}
+
+// Discover devuelve un plugin, deshabilitado, por cada ejecutable de dir,
+// ordenados por nombre. Que la carpeta no exista no es un error.
+func Discover(dir string) ([]*Plugin, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var plugins []*Plugin
+	for _, entry := range entries {
+		if !isExecutable(entry) {
+			continue
+		}
+		plugins = append(plugins, newPlugin(entry.Name(), filepath.Join(dir, entry.Name())))
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].id < plugins[j].id })
+	return plugins, nil
+}
+
+func isExecutable(entry os.DirEntry) bool {
+	info, err := entry.Info()
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".exe", ".bat", ".cmd":
+			return true
+		}
+		return false
+	}
+	return info.Mode().Perm()&0o111 != 0
+}
+
+// Plugin es un proveedor respaldado por un proceso externo. El proceso se
+// arranca al habilitarlo y, si se cae, se relanza en una búsqueda posterior;
+// sus fallos solo lo dejan fuera a él.
+type Plugin struct {
+	id      string // nombre del fichero; identifica al plugin en la configuración
+	path    string
+	enabled atomic.Bool
+
+	mu       sync.Mutex
+	info     provider.Info
+	proc     *process
+	starting bool
+	failedAt time.Time
+
+	iconsMu sync.Mutex
+	icons   map[string]fyne.Resource
+}
+
+func newPlugin(id, path string) *Plugin {
+	return &Plugin{
+		id:    id,
+		path:  path,
+		info:  provider.Info{Name: id},
+		icons: make(map[string]fyne.Resource),
+	}
+}
+
+func (p *Plugin) ID() string {
+	return p.id
+}
+
+func (p *Plugin) Enabled() bool {
+	return p.enabled.Load()
+}
+
+// SetEnabled arranca el plugin en segundo plano o lo detiene. Deshabilitado
+// pierde su prefijo para no acaparar las búsquedas que empiecen por él.
+func (p *Plugin) SetEnabled(enabled bool) {
+	p.enabled.Store(enabled)
+	if enabled {
+		logger.GoSafe(p.startLogged)
+		return
+	}
+	p.Stop()
+	p.mu.Lock()
+	p.info.Prefix = ""
+	p.mu.Unlock()
+}
+
+// Info devuelve lo que el plugin declaró al arrancar; hasta entonces solo
+// lleva su nombre de fichero.
+func (p *Plugin) Info() provider.Info {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.info
+}
+
+// Start lanza el proceso y le pide su descripción. No hace nada si ya está
+// en marcha o se cayó hace menos de restartDelay.
+func (p *Plugin) Start() error {
+	p.mu.Lock()
+	if p.starting || p.proc.alive() || time.Since(p.failedAt) < restartDelay {
+		p.mu.Unlock()
+		return nil
+	}
+	p.starting = true
+	p.mu.Unlock()
+
+	proc, err := startProcess(p.path)
+	var resp response
+	if err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), startTimeout)
+		resp, err = proc.call(ctx, request{Type: "info"})
+		cancel()
+		if err != nil {
+			proc.kill()
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.starting = false
+	if err != nil {
+		p.failedAt = time.Now()
+		return fmt.Errorf("plugin %s: %w", p.id, err)
+	}
+	if !p.enabled.Load() {
+		// Se deshabilitó mientras arrancaba.
+		proc.kill()
+		return nil
+	}
+	p.proc = proc
+	p.info = provider.Info{
+		Name:     resp.Name,
+		Prefix:   resp.Prefix,
+		Priority: resp.Priority,
+		Timeout:  queryTimeout(resp.TimeoutMS),
+	}
+	if p.info.Name == "" {
+		p.info.Name = p.id
+	}
+	return nil
+}
+
+// queryTimeout convierte el timeout_ms declarado, limitado a
+// maxQueryTimeout; sin él se usa provider.DefaultTimeout.
+func queryTimeout(ms int) time.Duration {
+	if ms <= 0 {
+		return provider.DefaultTimeout
+	}
+	return min(time.Duration(ms)*time.Millisecond, maxQueryTimeout)
+}
+
+func (p *Plugin) startLogged() {
+	if err := p.Start(); err != nil {
+		log.Printf("No se pudo iniciar el plugin: %v", err)
+	}
+}
+
+// Stop cierra el proceso del plugin, si lo hay.
+func (p *Plugin) Stop() {
+	p.mu.Lock()
+	proc := p.proc
+	p.proc = nil
+	p.mu.Unlock()
+	if proc != nil {
+		proc.kill()
+	}
+}
+
+// running devuelve el proceso en marcha; si se cayó, pide relanzarlo y
+// devuelve nil.
+func (p *Plugin) running() *process {
+	p.mu.Lock()
+	proc := p.proc
+	if proc != nil && !proc.alive() {
+		p.proc, proc = nil, nil
+		p.failedAt = time.Now()
+	}
+	p.mu.Unlock()
+
+	if proc == nil && p.enabled.Load() {
+		logger.GoSafe(p.startLogged)
+	}
+	return proc
+}
+
+func (p *Plugin) Query(ctx context.Context, query string) ([]provider.Result, error) {
+	if !p.enabled.Load() {
+		return nil, nil
+	}
+	proc := p.running()
+	if proc == nil {
+		return nil, nil
+	}
+	resp, err := proc.call(ctx, request{Type: "query", Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]provider.Result, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		result := provider.Result{
+			ID:       "plugin:" + p.id + ":" + r.ID,
+			Title:    r.Title,
+			Subtitle: r.Subtitle,
+			Icon:     p.icon(r.Icon),
+			Score:    r.Score,
+		}
+		for _, a := range r.Actions {
+			result.Actions = append(result.Actions, provider.Action{
+				ID:    a.ID,
+				Title: a.Title,
+				Run:   p.action(r.ID, a.ID),
+			})
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// action se ejecuta en segundo plano para no bloquear la UI mientras el
+// plugin responde; los errores solo se registran.
+func (p *Plugin) action(resultID, actionID string) func() error {
+	return func() error {
+		proc := p.running()
+		if proc == nil {
+			return errExited
+		}
+		logger.GoSafe(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+			defer cancel()
+			req := request{Type: "action", Result: resultID, Action: actionID}
+			if _, err := proc.call(ctx, req); err != nil {
+				log.Printf("Error en la acción %s del plugin %s: %v", actionID, p.id, err)
+			}
+		})
+		return nil
+	}
+}
+
+// icon carga y guarda en caché los iconos por ruta.
+func (p *Plugin) icon(path string) fyne.Resource {
+	if path == "" {
+		return nil
+	}
+	p.iconsMu.Lock()
+	defer p.iconsMu.Unlock()
+	if res, ok := p.icons[path]; ok {
+		return res
+	}
+	res, err := fyne.LoadResourceFromPath(path)
+	if err != nil {
+		res = nil
+	}
+	p.icons[path] = res
+	return res
+}
+
+// process es una instancia en marcha de un plugin. Las peticiones se
+// emparejan con sus respuestas por id.
+type process struct {
+	name  string
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	nextID  uint64
+	pending map[uint64]chan response
+	done    chan struct{} // se cierra cuando el proceso termina
+	killed  atomic.Bool   // lo cerró GoFinder; su salida no es un fallo
+}
+
+func startProcess(path string) (*process, error) {
+	cmd := exec.Command(path)
+	cmd.Dir = filepath.Dir(path)
+	cmd.Stderr = os.Stderr
+	hideWindow(cmd)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	proc := &process{
+		name:    filepath.Base(path),
+		cmd:     cmd,
+		stdin:   stdin,
+		pending: make(map[uint64]chan response),
+		done:    make(chan struct{}),
+	}
+	logger.GoSafe(func() { proc.readLoop(stdout) })
+	return proc, nil
+}
+
+func (pr *process) alive() bool {
+	if pr == nil {
+		return false
+	}
+	select {
+	case <-pr.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (pr *process) call(ctx context.Context, req request) (response, error) {
+	ch := make(chan response, 1)
+	pr.mu.Lock()
+	pr.nextID++
+	req.ID = pr.nextID
+	pr.pending[req.ID] = ch
+	pr.mu.Unlock()
+	defer func() {
+		pr.mu.Lock()
+		delete(pr.pending, req.ID)
+		pr.mu.Unlock()
+	}()
+
+	line, err := json.Marshal(req)
+	if err != nil {
+		return response{}, err
+	}
+	if err := ctx.Err(); err != nil {
+		return response{}, err
+	}
+	// La escritura se bloquea si el plugin deja de leer stdin, así que se
+	// hace aparte para poder respetar ctx.
+	written := make(chan error, 1)
+	logger.GoSafe(func() {
+		pr.writeMu.Lock()
+		defer pr.writeMu.Unlock()
+		_, err := pr.stdin.Write(append(line, '\n'))
+		written <- err
+	})
+	select {
+	case err := <-written:
+		if err != nil {
+			return response{}, err
+		}
+	case <-pr.done:
+		return response{}, errExited
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			// No lee stdin: se mata para que la escritura termine y el
+			// plugin se reinicie, en lugar de bloquear todas las consultas.
+			log.Printf("El plugin %s no acepta peticiones; se reinicia", pr.name)
+			_ = pr.cmd.Process.Kill()
+		}
+		return response{}, ctx.Err()
+	}
+
+	select {
+	case resp := <-ch:
+		if resp.Error != "" {
+			return resp, errors.New(resp.Error)
+		}
+		return resp, nil
+	case <-pr.done:
+		return response{}, errExited
+	case <-ctx.Done():
+		return response{}, ctx.Err()
+	}
+}
+
+// readLoop reparte las respuestas hasta que el plugin cierra stdout. Al
+// salir mata el proceso, por si dejó de responder con una línea inválida.
+func (pr *process) readLoop(stdout io.Reader) {
+	defer pr.exit()
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		var resp response
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			log.Printf("Respuesta no válida del plugin %s: %v", pr.name, err)
+			continue
+		}
+		pr.mu.Lock()
+		ch := pr.pending[resp.ID]
+		delete(pr.pending, resp.ID)
+		pr.mu.Unlock()
+		if ch != nil {
+			ch <- resp
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Printf("Error leyendo el plugin %s: %v", pr.name, err)
+	}
+}
+
+func (pr *process) exit() {
+	_ = pr.cmd.Process.Kill()
+	if err := pr.cmd.Wait(); err != nil && !pr.killed.Load() {
+		log.Printf("El plugin %s terminó: %v", pr.name, err)
+	}
+	close(pr.done)
+}
+
+func (pr *process) kill() {
+	pr.killed.Store(true)
+	_ = pr.stdin.Close()
+	_ = pr.cmd.Process.Kill()
+}
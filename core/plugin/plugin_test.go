@@ -0,0 +1,200 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adelylria/GoFinder/core/provider"
+)
+
+// El propio binario de test hace de plugin cuando se lanza con esta
+// variable de entorno.
+const fakePluginEnv = "GOFINDER_FAKE_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(fakePluginEnv) == "1" {
+		runFakePlugin()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runFakePlugin repite la consulta como resultado; "crash" lo tira, "slow"
+// tarda en responder y "deaf" deja de leer stdin.
+func runFakePlugin() {
+	scanner := bufio.NewScanner(os.Stdin)
+	out := json.NewEncoder(os.Stdout)
+	for scanner.Scan() {
+		var req request
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			fmt.Println("no es json")
+			continue
+		}
+		switch req.Type {
+		case "info":
+			out.Encode(response{ID: req.ID, Name: "Eco", Prefix: "e ", Priority: 2, TimeoutMS: 300})
+		case "query":
+			switch req.Query {
+			case "crash":
+				os.Exit(3)
+			case "slow":
+				time.Sleep(300 * time.Millisecond)
+			case "deaf":
+				time.Sleep(time.Hour)
+			}
+			out.Encode(response{ID: req.ID, Results: []wireResult{{
+				ID:      req.Query,
+				Title:   "eco " + req.Query,
+				Score:   500,
+				Actions: []wireAction{{ID: "copy", Title: "Copiar"}},
+			}}})
+		case "action":
+			resp := response{ID: req.ID}
+			if req.Action != "copy" {
+				resp.Error = "acción desconocida"
+			}
+			out.Encode(resp)
+		}
+	}
+}
+
+func startFakePlugin(t *testing.T) *Plugin {
+	t.Helper()
+	t.Setenv(fakePluginEnv, "1")
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("Executable: %v", err)
+	}
+	p := newPlugin("eco", exe)
+	p.enabled.Store(true)
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(p.Stop)
+	return p
+}
+
+func TestPluginQuery(t *testing.T) {
+	p := startFakePlugin(t)
+
+	info := p.Info()
+	if info.Name != "Eco" || info.Prefix != "e " || info.Priority != 2 || info.Timeout != 300*time.Millisecond {
+		t.Fatalf("Info() = %+v", info)
+	}
+
+	results, err := p.Query(context.Background(), "hola")
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "plugin:eco:hola" || results[0].Title != "eco hola" {
+		t.Fatalf("Query = %+v", results)
+	}
+	if len(results[0].Actions) != 1 || results[0].Actions[0].Title != "Copiar" {
+		t.Fatalf("Actions = %+v", results[0].Actions)
+	}
+
+	p.SetEnabled(false)
+	if results, _ := p.Query(context.Background(), "hola"); results != nil {
+		t.Fatalf("deshabilitado: Query = %+v", results)
+	}
+	if p.Info().Prefix != "" {
+		t.Fatalf("deshabilitado: Prefix = %q", p.Info().Prefix)
+	}
+}
+
+func TestPluginTimeout(t *testing.T) {
+	p := startFakePlugin(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := p.Query(ctx, "slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("slow: err = %v", err)
+	}
+
+	// La respuesta tardía de "slow" se descarta y no se confunde con esta.
+	results, err := p.Query(context.Background(), "rápido")
+	if err != nil || len(results) != 1 || results[0].Title != "eco rápido" {
+		t.Fatalf("tras timeout: %+v, %v", results, err)
+	}
+}
+
+func TestPluginCrash(t *testing.T) {
+	old := restartDelay
+	restartDelay = 0
+	t.Cleanup(func() { restartDelay = old })
+	p := startFakePlugin(t)
+
+	if _, err := p.Query(context.Background(), "crash"); !errors.Is(err, errExited) {
+		t.Fatalf("crash: err = %v", err)
+	}
+
+	// La siguiente búsqueda lo relanza en segundo plano.
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		results, err := p.Query(context.Background(), "otra")
+		if err == nil && len(results) == 1 {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("el plugin no se relanzó")
+}
+
+func TestPluginStopsReading(t *testing.T) {
+	old := restartDelay
+	restartDelay = 0
+	t.Cleanup(func() { restartDelay = old })
+	p := startFakePlugin(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := p.Query(ctx, "deaf"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("deaf: err = %v", err)
+	}
+
+	// Una petición mayor que el buffer del pipe bloquearía la escritura; al
+	// vencer el plazo se mata el plugin en lugar de quedarse esperando.
+	ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := p.Query(ctx, strings.Repeat("x", 256*1024)); err == nil {
+		t.Fatal("consulta grande: sin error")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("consulta grande: tardó %v", elapsed)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		results, err := p.Query(context.Background(), "otra")
+		if err == nil && len(results) == 1 {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("el plugin no se relanzó")
+}
+
+func TestQueryTimeout(t *testing.T) {
+	tests := []struct {
+		ms   int
+		want time.Duration
+	}{
+		{0, provider.DefaultTimeout},
+		{-5, provider.DefaultTimeout},
+		{300, 300 * time.Millisecond},
+		{60000, maxQueryTimeout},
+	}
+	for _, tt := range tests {
+		if got := queryTimeout(tt.ms); got != tt.want {
+			t.Fatalf("queryTimeout(%d) = %v, want %v", tt.ms, got, tt.want)
+		}
+	}
+}
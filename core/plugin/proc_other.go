@@ -0,0 +1,7 @@
+//go:build !windows
+
+package plugin
+
+import "os/exec"
+
+func hideWindow(cmd *exec.Cmd) {}
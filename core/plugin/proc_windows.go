@@ -0,0 +1,13 @@
+//go:build windows
+
+package plugin
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// hideWindow evita que cada plugin de consola abra su propia ventana.
+func hideWindow(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+}
@@ -0,0 +1,174 @@
+// Package provider define las fuentes de resultados del lanzador (apps,
+// calculadora, ficheros...) y mezcla lo que devuelven en una sola lista.
+package provider
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"github.com/adelylria/GoFinder/core/fuzzy"
+	"github.com/adelylria/GoFinder/core/logger"
+	"github.com/adelylria/GoFinder/models"
+)
+
+// DefaultTimeout es lo que se espera a un proveedor que no indica otro; los
+// que tarden más se quedan fuera de esa búsqueda.
+const DefaultTimeout = 150 * time.Millisecond
+
+// Info describe cómo se consulta un proveedor.
+type Info struct {
+	Name string
+	// Prefix, si no es vacío, es el disparador del proveedor: solo se le
+	// consulta cuando la búsqueda empieza por él, sin el prefijo, y entonces
+	// no se consulta a los proveedores sin prefijo.
+	Prefix string
+	// Priority ordena los resultados de distintos proveedores: los de mayor
+	// prioridad van antes, y a igual prioridad decide Result.Score.
+	Priority int
+	Timeout  time.Duration
+}
+
+// Action es algo que se puede hacer con un resultado; la primera de la
+// lista es la acción por defecto (Enter).
+type Action struct {
+	ID    string
+	Title string
+	Run   func() error
+}
+
+// Result es una fila de la lista.
+type Result struct {
+	ID       string // único entre todos los proveedores
+	Title    string
+	Subtitle string
+	Icon     fyne.Resource
+	// App, si el resultado es una aplicación, permite cargar su icono en
+	// segundo plano en lugar de usar Icon.
+	App *models.Application
+	// Score ordena los resultados de un mismo nivel de prioridad; conviene
+	// usar la escala de fuzzy (0-1000).
+	Score   int
+	Matches []fuzzy.Range // tramos de Title que coinciden con la búsqueda
+	Actions []Action
+	// Children son las filas a las que se entra con Tab o Right sobre el
+	// resultado, como las acciones de un .desktop.
+	Children []Result
+}
+
+type Provider interface {
+	Info() Info
+	Query(ctx context.Context, query string) ([]Result, error)
+}
+
+// Manager consulta a los proveedores registrados en paralelo.
+type Manager struct {
+	mu        sync.RWMutex
+	providers []Provider
+}
+
+func NewManager(providers ...Provider) *Manager {
+	return &Manager{providers: providers}
+}
+
+func (m *Manager) Register(p Provider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.providers = append(m.providers, p)
+}
+
+// Query consulta a los proveedores que aplican a query, cada uno con su
+// timeout, y mezcla sus resultados por prioridad y puntuación. A igualdad se
+// respeta el orden de registro y el orden de cada proveedor. Los errores de
+// un proveedor solo lo dejan fuera a él.
+func (m *Manager) Query(ctx context.Context, query string) []Result {
+	type call struct {
+		provider Provider
+		info     Info
+		query    string
+	}
+	m.mu.RLock()
+	var calls []call
+	for _, p := range m.providers {
+		info := p.Info()
+		if info.Prefix != "" && strings.HasPrefix(query, info.Prefix) {
+			calls = append(calls, call{p, info, strings.TrimPrefix(query, info.Prefix)})
+		}
+	}
+	if len(calls) == 0 {
+		for _, p := range m.providers {
+			if info := p.Info(); info.Prefix == "" {
+				calls = append(calls, call{p, info, query})
+			}
+		}
+	}
+	m.mu.RUnlock()
+
+	type ranked struct {
+		Result
+		priority int
+	}
+	perCall := make([][]ranked, len(calls))
+	var wg sync.WaitGroup
+	for i, c := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results := queryWithTimeout(ctx, c.provider, c.info, c.query)
+			for _, r := range results {
+				perCall[i] = append(perCall[i], ranked{r, c.info.Priority})
+			}
+		}()
+	}
+	wg.Wait()
+
+	var merged []ranked
+	for _, results := range perCall {
+		merged = append(merged, results...)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].priority != merged[j].priority {
+			return merged[i].priority > merged[j].priority
+		}
+		return merged[i].Score > merged[j].Score
+	})
+
+	out := make([]Result, len(merged))
+	for i, r := range merged {
+		out[i] = r.Result
+	}
+	return out
+}
+
+// queryWithTimeout devuelve nil si el proveedor falla, entra en pánico o no
+// responde a tiempo; su goroutine termina sola cuando el proveedor ve el
+// contexto cancelado.
+func queryWithTimeout(ctx context.Context, p Provider, info Info, query string) []Result {
+	timeout := info.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan []Result, 1)
+	logger.GoSafe(func() {
+		results, err := p.Query(ctx, query)
+		if err != nil {
+			log.Printf("Error en el proveedor %s: %v", info.Name, err)
+			results = nil
+		}
+		done <- results
+	})
+
+	select {
+	case results := <-done:
+		return results
+	case <-ctx.Done():
+		return nil
+	}
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fakeProvider struct {
+	info    Info
+	results []Result
+	err     error
+	delay   time.Duration
+	got     string
+}
+
+func (f *fakeProvider) Info() Info { return f.info }
+
+func (f *fakeProvider) Query(ctx context.Context, query string) ([]Result, error) {
+	f.got = query
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return f.results, f.err
+}
+
+func ids(results []Result) []string {
+	out := make([]string, len(results))
+	for i, r := range results {
+		out[i] = r.ID
+	}
+	return out
+}
+
+func TestManagerMerge(t *testing.T) {
+	apps := &fakeProvider{
+		info:    Info{Name: "apps"},
+		results: []Result{{ID: "a1", Score: 800}, {ID: "a2", Score: 200}},
+	}
+	files := &fakeProvider{
+		info:    Info{Name: "files", Priority: -1},
+		results: []Result{{ID: "f1", Score: 1000}},
+	}
+	calc := &fakeProvider{
+		info:    Info{Name: "calc", Priority: 10},
+		results: []Result{{ID: "c1"}},
+	}
+	more := &fakeProvider{
+		info:    Info{Name: "more"},
+		results: []Result{{ID: "m1", Score: 800}, {ID: "m2", Score: 600}},
+	}
+	broken := &fakeProvider{info: Info{Name: "broken"}, err: errors.New("boom")}
+	slow := &fakeProvider{
+		info:    Info{Name: "slow", Timeout: 10 * time.Millisecond},
+		results: []Result{{ID: "s1", Score: 1000}},
+		delay:   time.Second,
+	}
+
+	m := NewManager(apps, files, calc, more, broken, slow)
+	got := ids(m.Query(context.Background(), "x"))
+	want := []string{"c1", "a1", "m1", "m2", "a2", "f1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Query = %v, want %v", got, want)
+	}
+}
+
+func TestManagerPrefix(t *testing.T) {
+	apps := &fakeProvider{info: Info{Name: "apps"}, results: []Result{{ID: "app"}}}
+	shell := &fakeProvider{info: Info{Name: "shell", Prefix: ">"}, results: []Result{{ID: "cmd"}}}
+	m := NewManager(apps, shell)
+
+	if got := ids(m.Query(context.Background(), ">ls -l")); !reflect.DeepEqual(got, []string{"cmd"}) {
+		t.Fatalf("prefixed query = %v", got)
+	}
+	if shell.got != "ls -l" {
+		t.Fatalf("shell got %q, want the query without prefix", shell.got)
+	}
+	if got := ids(m.Query(context.Background(), "ls")); !reflect.DeepEqual(got, []string{"app"}) {
+		t.Fatalf("plain query = %v", got)
+	}
+}
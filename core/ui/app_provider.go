@@ -0,0 +1,458 @@
+package ui
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/adelylria/GoFinder/core/fuzzy"
+	"github.com/adelylria/GoFinder/core/history"
+	"github.com/adelylria/GoFinder/core/i18n"
+	"github.com/adelylria/GoFinder/core/provider"
+	"github.com/adelylria/GoFinder/logic"
+	"github.com/adelylria/GoFinder/models"
+)
+
+// appQueryTimeout es generoso a propósito: si ordenar muchas apps en una
+// máquina lenta superase provider.DefaultTimeout, desaparecerían todas de la
+// lista.
+const appQueryTimeout = 2 * time.Second
+
+// appProvider es el proveedor de aplicaciones instaladas. Se consulta desde
+// la goroutine de búsqueda y se actualiza desde la UI y el descubrimiento,
+// de ahí el mutex.
+type appProvider struct {
+	mu       sync.RWMutex
+	apps     map[string]models.Application
+	history  *history.History
+	frecency map[string]float64 // por ID de app
+}
+
+func newAppProvider(apps []models.Application, h *history.History) *appProvider {
+	p := &appProvider{apps: createAppMap(apps), history: h}
+	p.refreshFrecency()
+	return p
+}
+
+func (p *appProvider) Info() provider.Info {
+	return provider.Info{Name: "apps", Timeout: appQueryTimeout}
+}
+
+func (p *appProvider) Query(ctx context.Context, query string) ([]provider.Result, error) {
+	picks := p.queryPicks(query)
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	ranked := rankApps(query, p.apps, p.frecency, picks)
+	matcher := fuzzy.NewMatcher(query)
+	results := make([]provider.Result, 0, len(ranked))
+	for _, r := range ranked {
+		app := p.apps[r.id]
+		title, matches := appTitle(app, r.untranslated, matcher)
+		results = append(results, provider.Result{
+			ID:      app.ID,
+			Title:   title,
+			App:     &app,
+			Score:   appScore(r, picks),
+			Matches: matches,
+			Actions: []provider.Action{{
+				ID:    "open",
+				Title: i18n.T(i18n.ActionOpen),
+				Run:   func() error { return p.launch(app, query) },
+			}},
+			Children: p.actionResults(app, query),
+		})
+	}
+	results = append(results, p.matchActions(query)...)
+	return results, ctx.Err()
+}
+
+// actionSeparator separa la app de la acción en el título de las acciones
+// que salen en la búsqueda.
+const actionSeparator = " › "
+
+func (p *appProvider) actionResult(app models.Application, action models.DesktopAction, query string) provider.Result {
+	actionApp := app.ForAction(action)
+	return provider.Result{
+		ID:    actionApp.ID,
+		Title: action.Name,
+		App:   &actionApp,
+		Actions: []provider.Action{{
+			ID:    "open",
+			Title: i18n.T(i18n.ActionOpen),
+			Run:   func() error { return p.launchAction(app, actionApp, query) },
+		}},
+	}
+}
+
+// actionResults devuelve las acciones de app como subentradas.
+func (p *appProvider) actionResults(app models.Application, query string) []provider.Result {
+	if len(app.DesktopActions) == 0 {
+		return nil
+	}
+	results := make([]provider.Result, 0, len(app.DesktopActions))
+	for _, action := range app.DesktopActions {
+		results = append(results, p.actionResult(app, action, query))
+	}
+	return results
+}
+
+// matchActions busca las acciones cuando la consulta es "app acción", como
+// "firefox privada": se prueba cada espacio como separador y la parte de
+// delante tiene que encajar con el nombre de la app y la de detrás con el
+// de la acción. Debe llamarse con p.mu tomado.
+func (p *appProvider) matchActions(query string) []provider.Result {
+	var splits [][2]*fuzzy.Matcher
+	for i, r := range query {
+		if r != ' ' {
+			continue
+		}
+		head, tail := strings.TrimSpace(query[:i]), strings.TrimSpace(query[i+1:])
+		if head != "" && tail != "" {
+			splits = append(splits, [2]*fuzzy.Matcher{fuzzy.NewMatcher(head), fuzzy.NewMatcher(tail)})
+		}
+	}
+	if len(splits) == 0 {
+		return nil
+	}
+
+	type rankedAction struct {
+		result provider.Result
+		tier   fuzzy.Tier
+		score  int
+	}
+	var ranked []rankedAction
+	for _, app := range p.apps {
+		for _, action := range app.DesktopActions {
+			var best *actionMatch
+			for _, split := range splits {
+				if m, ok := matchAction(split, app, action); ok && (best == nil || m.better(*best)) {
+					best = &m
+				}
+			}
+			if best == nil {
+				continue
+			}
+			result := p.actionResult(app, action, query)
+			result.Title, result.Matches = best.title(app, action)
+			// Misma escala que las filas de apps, que puntúan por nivel: la
+			// acción vale lo que la peor de sus dos partes.
+			result.Score = best.tier.Base()
+			ranked = append(ranked, rankedAction{result, best.tier, best.score})
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.tier != b.tier {
+			return a.tier > b.tier
+		}
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		return a.result.Title < b.result.Title
+	})
+	results := make([]provider.Result, len(ranked))
+	for i, r := range ranked {
+		results[i] = r.result
+	}
+	return results
+}
+
+// actionMatch es cómo encaja una consulta "app acción" con una acción.
+type actionMatch struct {
+	split        [2]*fuzzy.Matcher
+	untranslated bool // la app encajó por UntranslatedName
+	tier         fuzzy.Tier
+	score        int
+}
+
+// matchAction prueba la parte de delante con los dos nombres de la app, como
+// rankApps, y la de detrás con el de la acción.
+func matchAction(split [2]*fuzzy.Matcher, app models.Application, action models.DesktopAction) (actionMatch, bool) {
+	tail, ok := split[1].Score(action.Name)
+	if !ok {
+		return actionMatch{}, false
+	}
+	var best actionMatch
+	found := false
+	for i, name := range []string{app.Name, app.UntranslatedName} {
+		if name == "" {
+			continue
+		}
+		head, ok := split[0].Score(name)
+		if !ok {
+			continue
+		}
+		m := actionMatch{split, i == 1, min(head.Tier, tail.Tier), head.Score + tail.Score}
+		if !found || m.better(best) {
+			best, found = m, true
+		}
+	}
+	return best, found
+}
+
+func (m actionMatch) better(other actionMatch) bool {
+	if m.tier != other.tier {
+		return m.tier > other.tier
+	}
+	return m.score > other.score
+}
+
+// title devuelve "app › acción" con los tramos de las dos partes.
+func (m actionMatch) title(app models.Application, action models.DesktopAction) (string, []fuzzy.Range) {
+	title, ranges := appTitle(app, m.untranslated, m.split[0])
+	offset := utf8.RuneCountInString(title + actionSeparator)
+	for _, r := range m.split[1].Ranges(action.Name) {
+		ranges = append(ranges, fuzzy.Range{Start: r.Start + offset, End: r.End + offset})
+	}
+	return title + actionSeparator + action.Name, ranges
+}
+
+func (p *appProvider) launch(app models.Application, query string) error {
+	log.Printf(i18n.T(i18n.LogRunningApp), app.Name, app.Exec)
+	if err := logic.RunApplication(app); err != nil {
+		return err
+	}
+	p.recordLaunch(app, query)
+	return nil
+}
+
+// launchAction lanza una acción de app; cuenta en el historial como un
+// lanzamiento de la propia app.
+func (p *appProvider) launchAction(app, actionApp models.Application, query string) error {
+	log.Printf(i18n.T(i18n.LogRunningApp), actionApp.Name, actionApp.Exec)
+	if err := logic.RunApplication(actionApp); err != nil {
+		return err
+	}
+	p.recordLaunch(app, query)
+	return nil
+}
+
+// Apps devuelve una copia de las apps actuales.
+func (p *appProvider) Apps() []models.Application {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	apps := make([]models.Application, 0, len(p.apps))
+	for _, app := range p.apps {
+		apps = append(apps, app)
+	}
+	return apps
+}
+
+// FindByDesktopName busca la app que el registro de documentos recientes
+// llama name: su ID de fichero .desktop, con o sin extensión, o su nombre.
+func (p *appProvider) FindByDesktopName(name string) (models.Application, bool) {
+	if name == "" {
+		return models.Application{}, false
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if app, ok := p.apps[models.DesktopAppID(name+".desktop")]; ok {
+		return app, true
+	}
+	if app, ok := p.apps[models.DesktopAppID(name)]; ok {
+		return app, true
+	}
+	var found models.Application
+	ok := false
+	for _, app := range p.apps {
+		if !strings.EqualFold(app.Name, name) && !strings.EqualFold(app.UntranslatedName, name) {
+			continue
+		}
+		// Con varias del mismo nombre, la elección no depende del orden del mapa.
+		if !ok || app.ID < found.ID {
+			found, ok = app, true
+		}
+	}
+	return found, ok
+}
+
+func (p *appProvider) SetApps(apps []models.Application) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.apps = createAppMap(apps)
+}
+
+func (p *appProvider) AddApps(apps []models.Application) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, app := range apps {
+		p.apps[app.ID] = app
+	}
+}
+
+func (p *appProvider) ApplyChanges(changes []models.AppChange) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, change := range changes {
+		if change.App == nil {
+			delete(p.apps, change.ID)
+			continue
+		}
+		p.apps[change.ID] = *change.App
+	}
+}
+
+// recordLaunch guarda el lanzamiento en el historial y recalcula la
+// frecency para que el siguiente listado ya lo tenga en cuenta.
+func (p *appProvider) recordLaunch(app models.Application, query string) {
+	if p.history == nil {
+		return
+	}
+	if err := p.history.Record(app.ID, query, time.Now()); err != nil {
+		log.Printf("Error guardando historial: %v", err)
+	}
+	p.refreshFrecency()
+}
+
+func (p *appProvider) clearHistory() error {
+	if p.history == nil {
+		return nil
+	}
+	err := p.history.Clear()
+	p.refreshFrecency()
+	return err
+}
+
+func (p *appProvider) refreshFrecency() {
+	var frecency map[string]float64
+	if p.history != nil {
+		frecency = p.history.Frecency(time.Now())
+	}
+	p.mu.Lock()
+	p.frecency = frecency
+	p.mu.Unlock()
+}
+
+// queryPicks devuelve cuántas veces se eligió cada app tras escribir una
+// consulta que empieza por query.
+func (p *appProvider) queryPicks(query string) map[string]int {
+	if p.history == nil {
+		return nil
+	}
+	return p.history.Picks(query)
+}
+
+// migrateHistoryKeys pasa las entradas guardadas antes de que los IDs fueran
+// estables, que usaban el ID de fichero .desktop o el ejecutable, al ID
+// actual de cada app. Los UUID antiguos nunca se guardaron.
+func migrateHistoryKeys(h *history.History, apps []models.Application) {
+	if h == nil {
+		return
+	}
+	renames := make(map[string]string)
+	for _, app := range apps {
+		legacy := app.DesktopID
+		if legacy == "" {
+			legacy = app.Exec
+		}
+		if legacy != "" {
+			renames[legacy] = app.ID
+		}
+	}
+	if err := h.RenameApps(renames); err != nil {
+		log.Printf("Error migrando historial: %v", err)
+	}
+}
+
+func createAppMap(apps []models.Application) map[string]models.Application {
+	appMap := make(map[string]models.Application)
+	for _, app := range apps {
+		appMap[app.ID] = app
+	}
+	return appMap
+}
+
+type rankedApp struct {
+	id    string
+	match fuzzy.Match
+	// untranslated indica que la coincidencia es con UntranslatedName.
+	untranslated bool
+}
+
+// appTitle devuelve el título de la app y los tramos resaltados. Si lo que
+// encajó fue el nombre sin traducir, se muestra entre paréntesis para que
+// se vea por qué sale.
+func appTitle(app models.Application, untranslated bool, matcher *fuzzy.Matcher) (string, []fuzzy.Range) {
+	if !untranslated {
+		return app.Name, matcher.Ranges(app.Name)
+	}
+	title := app.Name + " (" + app.UntranslatedName + ")"
+	offset := utf8.RuneCountInString(app.Name + " (")
+	ranges := matcher.Ranges(app.UntranslatedName)
+	for i := range ranges {
+		ranges[i].Start += offset
+		ranges[i].End += offset
+	}
+	return title, ranges
+}
+
+// appPickScore pone las apps elegidas para el prefijo por encima de cualquier
+// nivel de coincidencia, también en la mezcla con otros proveedores.
+var appPickScore = 2 * fuzzy.TierExact.Base()
+
+// appScore es la base del nivel de coincidencia o, si la app se ha elegido
+// para este prefijo, appPickScore más las veces que se eligió.
+func appScore(r rankedApp, picks map[string]int) int {
+	if n := picks[r.id]; n > 0 {
+		return appPickScore + n
+	}
+	return r.match.Tier.Base()
+}
+
+// rankApps devuelve las apps que encajan con filter. Primero van las elegidas
+// más veces para ese prefijo (picks), aunque la coincidencia sea más débil;
+// después decide el tipo de coincidencia, la puntuación fuzzy, la frecency y
+// el nombre. Se prueba tanto el nombre traducido como el original. Sin filtro
+// devuelve todas, las más usadas primero y el resto por nombre.
+func rankApps(filter string, appMap map[string]models.Application, frecency map[string]float64, picks map[string]int) []rankedApp {
+	matcher := fuzzy.NewMatcher(filter)
+	ranked := make([]rankedApp, 0, len(appMap))
+	for id, app := range appMap {
+		var best rankedApp
+		found := false
+		for i, name := range []string{app.Name, app.UntranslatedName} {
+			if name == "" {
+				continue
+			}
+			if m, ok := matcher.Score(name); ok && (!found || m.Score > best.match.Score) {
+				best, found = rankedApp{id, m, i == 1}, true
+			}
+		}
+		if found {
+			ranked = append(ranked, best)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if picks[a.id] != picks[b.id] {
+			return picks[a.id] > picks[b.id]
+		}
+		if a.match.Tier != b.match.Tier {
+			return a.match.Tier > b.match.Tier
+		}
+		if a.match.Score != b.match.Score {
+			return a.match.Score > b.match.Score
+		}
+		if frecency[a.id] != frecency[b.id] {
+			return frecency[a.id] > frecency[b.id]
+		}
+		return appLess(appMap[a.id], appMap[b.id])
+	})
+	return ranked
+}
+
+// appLess ordena por nombre sin distinguir mayúsculas y, para que el orden
+// sea estable entre ejecuciones, desempata por ID.
+func appLess(a, b models.Application) bool {
+	an, bn := strings.ToLower(a.Name), strings.ToLower(b.Name)
+	if an != bn {
+		return an < bn
+	}
+	return a.ID < b.ID
+}
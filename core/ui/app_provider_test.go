@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/adelylria/GoFinder/core/fuzzy"
+	"github.com/adelylria/GoFinder/core/history"
+	"github.com/adelylria/GoFinder/models"
+)
+
+func TestAppProviderQuery(t *testing.T) {
+	h, err := history.LoadFile(filepath.Join(t.TempDir(), "history.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	apps := []models.Application{
+		{ID: "terminal", Name: "Terminal"},
+		{ID: "telegram", Name: "Telegram"},
+		{ID: "text", Name: "GNOME Text Editor"},
+		{ID: "steam", Name: "Steam"},
+	}
+	_ = h.Record("telegram", "te", time.Now())
+	_ = h.Record("steam", "", time.Now())
+	p := newAppProvider(apps, h)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"steam", "telegram", "text", "terminal"}},
+		{"te", []string{"telegram", "terminal", "text", "steam"}},
+		{"ter", []string{"terminal", "telegram", "text"}},
+	}
+	for _, tt := range tests {
+		results, err := p.Query(context.Background(), tt.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, r := range results {
+			got = append(got, r.ID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("Query(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestAppProviderPickBeatsTier(t *testing.T) {
+	h, err := history.LoadFile(filepath.Join(t.TempDir(), "history.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	apps := []models.Application{
+		{ID: "telegram", Name: "Telegram"},
+		{ID: "text", Name: "GNOME Text Editor"},
+	}
+	// "GNOME Text Editor" solo encaja por inicio de palabra con "te", pero es
+	// la que se elige.
+	_ = h.Record("text", "te", time.Now())
+	_ = h.Record("text", "tex", time.Now())
+	p := newAppProvider(apps, h)
+
+	results, err := p.Query(context.Background(), "te")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].ID != "text" {
+		t.Fatalf("Query(te) = %+v, se esperaba text primero", results)
+	}
+	if results[0].Score <= results[1].Score {
+		t.Fatalf("score %d de la app elegida no supera %d", results[0].Score, results[1].Score)
+	}
+}
+
+func TestAppProviderActions(t *testing.T) {
+	firefox := models.Application{
+		ID:   "desktop:firefox.desktop",
+		Name: "Firefox",
+		Exec: "firefox %u",
+		DesktopActions: []models.DesktopAction{
+			{ID: "new-window", Name: "New Window", Exec: "firefox --new-window"},
+			{ID: "new-private-window", Name: "New Private Window", Exec: "firefox --private-window", Icon: "firefox-private", IconPath: "/icons/private.png"},
+		},
+	}
+	p := newAppProvider([]models.Application{firefox, {ID: "files", Name: "Files"}}, nil)
+
+	results, err := p.Query(context.Background(), "fire")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || len(results[0].Children) != 2 {
+		t.Fatalf("Query(fire) = %+v", results)
+	}
+	private := results[0].Children[1]
+	if private.Title != "New Private Window" || private.App.Exec != "firefox --private-window" || private.App.IconPath != "/icons/private.png" {
+		t.Fatalf("subentrada = %q %+v", private.Title, private.App)
+	}
+
+	// "app acción" encuentra la acción directamente.
+	results, err = p.Query(context.Background(), "firefox priv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var titles []string
+	for _, r := range results {
+		titles = append(titles, r.Title)
+	}
+	if want := []string{"Firefox › New Private Window"}; !reflect.DeepEqual(titles, want) {
+		t.Fatalf("Query(firefox priv) = %q, want %q", titles, want)
+	}
+	want := []fuzzy.Range{{Start: 0, End: 7}, {Start: 14, End: 18}}
+	if got := results[0].Matches; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Matches = %v, want %v", got, want)
+	}
+	// Puntúa en la escala de niveles de las apps, por la peor de las partes:
+	// "priv" solo encaja al inicio de una palabra.
+	if got := results[0].Score; got != fuzzy.TierWordStart.Base() {
+		t.Fatalf("Score = %d, want %d", got, fuzzy.TierWordStart.Base())
+	}
+
+	// La app también encaja por su nombre sin traducir.
+	files := models.Application{
+		ID: "files", Name: "Archivos", UntranslatedName: "Files",
+		DesktopActions: []models.DesktopAction{{ID: "new-window", Name: "Nueva ventana", Exec: "nautilus --new-window"}},
+	}
+	p = newAppProvider([]models.Application{files}, nil)
+	results, err = p.Query(context.Background(), "files nueva")
+	if err != nil || len(results) != 1 {
+		t.Fatalf("Query(files nueva) = %+v, %v", results, err)
+	}
+	if got, want := results[0].Title, "Archivos (Files) › Nueva ventana"; got != want {
+		t.Fatalf("Title = %q, want %q", got, want)
+	}
+	want = []fuzzy.Range{{Start: 10, End: 15}, {Start: 19, End: 24}}
+	if got := results[0].Matches; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Matches = %v, want %v", got, want)
+	}
+}
+
+func TestAppProviderUntranslatedMatch(t *testing.T) {
+	p := newAppProvider([]models.Application{{ID: "files", Name: "Archivos", UntranslatedName: "Files"}}, nil)
+
+	tests := []struct {
+		query   string
+		title   string
+		matches []fuzzy.Range
+	}{
+		{"arch", "Archivos", []fuzzy.Range{{Start: 0, End: 4}}},
+		{"fil", "Archivos (Files)", []fuzzy.Range{{Start: 10, End: 13}}},
+	}
+	for _, tt := range tests {
+		results, err := p.Query(context.Background(), tt.query)
+		if err != nil || len(results) != 1 {
+			t.Fatalf("Query(%q) = %+v, %v", tt.query, results, err)
+		}
+		if results[0].Title != tt.title || !reflect.DeepEqual(results[0].Matches, tt.matches) {
+			t.Fatalf("Query(%q) = %q %v, want %q %v", tt.query, results[0].Title, results[0].Matches, tt.title, tt.matches)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"context"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+
+	"github.com/adelylria/GoFinder/core/calc"
+	"github.com/adelylria/GoFinder/core/i18n"
+	"github.com/adelylria/GoFinder/core/provider"
+)
+
+// calcPriority pone el resultado de la calculadora y de las conversiones por
+// encima de las apps.
+const calcPriority = 10
+
+// calcProvider muestra el valor de la búsqueda cuando es una expresión
+// matemática; Enter lo copia al portapapeles.
+type calcProvider struct{}
+
+func (calcProvider) Info() provider.Info {
+	return provider.Info{Name: "calc", Priority: calcPriority}
+}
+
+func (calcProvider) Query(_ context.Context, query string) ([]provider.Result, error) {
+	res, err := calc.Eval(query)
+	if err != nil {
+		return nil, nil
+	}
+	value := res.String()
+	return []provider.Result{{
+		ID:       "calc",
+		Title:    "= " + value,
+		Subtitle: strings.TrimSpace(query),
+		Icon:     theme.ContentCopyIcon(),
+		Actions: []provider.Action{{
+			ID:    "copy",
+			Title: i18n.T(i18n.ActionCopy),
+			Run:   func() error { return copyToClipboard(value) },
+		}},
+	}}, nil
+}
+
+func copyToClipboard(text string) error {
+	fyne.CurrentApp().Clipboard().SetContent(text)
+	return nil
+}
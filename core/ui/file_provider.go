@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"context"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+
+	"github.com/adelylria/GoFinder/core/fuzzy"
+	"github.com/adelylria/GoFinder/core/i18n"
+	"github.com/adelylria/GoFinder/core/logger"
+	"github.com/adelylria/GoFinder/core/provider"
+	"github.com/adelylria/GoFinder/logic"
+	"github.com/adelylria/GoFinder/logic/files"
+)
+
+const (
+	filePrefix     = "/"
+	maxFileResults = 50
+	// fileIndexMaxAge es cada cuánto, como mucho, una búsqueda de ficheros
+	// lanza un refresco del índice en segundo plano.
+	fileIndexMaxAge = 2 * time.Minute
+	// nameMatchBonus pone las coincidencias en el nombre por delante de las
+	// que solo encajan con la ruta.
+	nameMatchBonus = 1000
+)
+
+// fileProvider busca en el índice de ficheros cuando la búsqueda empieza
+// por "/". Enter abre el fichero; la segunda acción lo muestra en su
+// carpeta.
+type fileProvider struct {
+	index *files.Index
+}
+
+func newFileProvider(index *files.Index) *fileProvider {
+	return &fileProvider{index: index}
+}
+
+func (p *fileProvider) Info() provider.Info {
+	return provider.Info{Name: "files", Prefix: filePrefix, Timeout: 500 * time.Millisecond}
+}
+
+// LoadInBackground recupera el índice guardado y lo pone al día.
+func (p *fileProvider) LoadInBackground() {
+	logger.GoSafe(func() {
+		if err := p.index.Load(); err != nil {
+			log.Printf("Error cargando índice de ficheros: %v", err)
+		}
+		p.refresh()
+	})
+}
+
+// RefreshInBackground actualiza el índice si hace tiempo que no se hace.
+func (p *fileProvider) RefreshInBackground() {
+	if p.index.Stale(fileIndexMaxAge) {
+		logger.GoSafe(p.refresh)
+	}
+}
+
+func (p *fileProvider) refresh() {
+	if err := p.index.Refresh(context.Background()); err != nil {
+		log.Printf("Error indexando ficheros: %v", err)
+	}
+}
+
+type rankedFile struct {
+	entry files.Entry
+	score int
+	// nameMatch indica que los tramos de la coincidencia son del nombre y
+	// hay que desplazarlos al final de Rel.
+	nameMatch bool
+}
+
+func (p *fileProvider) Query(ctx context.Context, query string) ([]provider.Result, error) {
+	p.RefreshInBackground()
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil
+	}
+
+	matcher := fuzzy.NewMatcher(query)
+	var ranked []rankedFile
+	for i, entry := range p.index.Entries() {
+		if i%4096 == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if m, ok := matcher.Score(entry.Name); ok {
+			ranked = append(ranked, rankedFile{entry, m.Score + nameMatchBonus, true})
+		} else if m, ok := matcher.Score(entry.Rel); ok {
+			ranked = append(ranked, rankedFile{entry, m.Score, false})
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return len(ranked[i].entry.Rel) < len(ranked[j].entry.Rel)
+	})
+	if len(ranked) > maxFileResults {
+		ranked = ranked[:maxFileResults]
+	}
+
+	results := make([]provider.Result, 0, len(ranked))
+	for _, r := range ranked {
+		entry := r.entry
+		results = append(results, provider.Result{
+			ID:       "file:" + entry.Path,
+			Title:    entry.Rel,
+			Subtitle: entry.Path,
+			Icon:     fileIcon(entry.Name, entry.Dir),
+			Score:    r.score,
+			Matches:  fileMatches(matcher, r),
+			Actions: []provider.Action{
+				{ID: "open", Title: i18n.T(i18n.ActionOpen), Run: func() error { return logic.OpenPath(entry.Path) }},
+				{ID: "reveal", Title: i18n.T(i18n.ActionReveal), Run: func() error { return logic.RevealPath(entry.Path) }},
+			},
+		})
+	}
+	return results, nil
+}
+
+// fileMatches devuelve los tramos resaltados sobre Rel, que es el título.
+func fileMatches(matcher *fuzzy.Matcher, r rankedFile) []fuzzy.Range {
+	if !r.nameMatch {
+		return matcher.Ranges(r.entry.Rel)
+	}
+	offset := utf8.RuneCountInString(r.entry.Rel) - utf8.RuneCountInString(r.entry.Name)
+	ranges := matcher.Ranges(r.entry.Name)
+	for i := range ranges {
+		ranges[i].Start += offset
+		ranges[i].End += offset
+	}
+	return ranges
+}
+
+// fileIcon elige el icono del tema según la extensión de name.
+func fileIcon(name string, dir bool) fyne.Resource {
+	if dir {
+		return theme.FolderIcon()
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".png", ".jpg", ".jpeg", ".gif", ".svg", ".webp", ".bmp":
+		return theme.FileImageIcon()
+	case ".mp3", ".ogg", ".flac", ".wav", ".m4a":
+		return theme.FileAudioIcon()
+	case ".mp4", ".mkv", ".webm", ".avi", ".mov":
+		return theme.FileVideoIcon()
+	case ".txt", ".md", ".log", ".csv", ".json", ".go", ".py", ".js":
+		return theme.FileTextIcon()
+	}
+	return theme.FileIcon()
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/adelylria/GoFinder/core/fuzzy"
+	"github.com/adelylria/GoFinder/logic/files"
+)
+
+func TestFileProviderQuery(t *testing.T) {
+	root := t.TempDir()
+	for _, path := range []string{"docs/report.pdf", "reports/2024/q1.txt", "notes.md"} {
+		full := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	index := files.NewIndex([]string{root}, files.DefaultIgnore, "")
+	if err := index.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	p := newFileProvider(index)
+
+	results, err := p.Query(context.Background(), "report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var titles []string
+	for _, r := range results {
+		titles = append(titles, r.Title)
+	}
+	// Primero las coincidencias en el nombre; luego las que solo encajan
+	// con la ruta, más cortas antes.
+	want := []string{"reports", "docs/report.pdf", "reports/2024", "reports/2024/q1.txt"}
+	if !reflect.DeepEqual(titles, want) {
+		t.Fatalf("Query(report) = %q, want %q", titles, want)
+	}
+	if got := results[1].Matches; !reflect.DeepEqual(got, []fuzzy.Range{{Start: 5, End: 11}}) {
+		t.Fatalf("Matches = %v", got)
+	}
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"sync"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/adelylria/GoFinder/core/logger"
+	"github.com/adelylria/GoFinder/logic"
+	"github.com/adelylria/GoFinder/models"
+)
+
+const (
+	iconLoaderWorkers  = 4
+	iconLoaderQueueMax = 256
+	// iconPrefetchRows es cuántas filas por encima y por debajo de cada fila
+	// dibujada se piden por adelantado.
+	iconPrefetchRows = 6
+)
+
+// iconLoader carga los iconos de la lista en un pool de goroutines para no
+// bloquear el hilo de la UI con lecturas de disco o llamadas Win32. La cola
+// es LIFO: al hacer scroll rápido, las filas que se acaban de pedir (las
+// visibles) se atienden antes que las que ya quedaron atrás.
+type iconLoader struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	queue    []models.Application
+	pending  map[string]bool
+	missing  map[string]bool
+	onLoaded func(appID string)
+}
+
+func newIconLoader(onLoaded func(appID string)) *iconLoader {
+	il := &iconLoader{
+		pending:  make(map[string]bool),
+		missing:  make(map[string]bool),
+		onLoaded: onLoaded,
+	}
+	il.cond = sync.NewCond(&il.mu)
+	for i := 0; i < iconLoaderWorkers; i++ {
+		logger.GoSafe(il.work)
+	}
+	return il
+}
+
+// Icon devuelve el icono si ya está en caché. Si no, lo encola y devuelve
+// loading=true para que la fila muestre un icono provisional.
+func (il *iconLoader) Icon(app models.Application) (res fyne.Resource, loading bool) {
+	if res, ok := logic.CachedAppIcon(app); ok {
+		return res, false
+	}
+
+	il.mu.Lock()
+	defer il.mu.Unlock()
+	if il.missing[app.ID] {
+		return nil, false
+	}
+	il.enqueueLocked(app)
+	return nil, true
+}
+
+// Prefetch encola el icono de una fila que todavía no es visible.
+func (il *iconLoader) Prefetch(app models.Application) {
+	il.Icon(app)
+}
+
+// Forget olvida que la app no tenía icono, para reintentarlo tras un cambio
+// en su fichero.
+func (il *iconLoader) Forget(appID string) {
+	il.mu.Lock()
+	defer il.mu.Unlock()
+	delete(il.missing, appID)
+}
+
+func (il *iconLoader) enqueueLocked(app models.Application) {
+	if il.pending[app.ID] {
+		return
+	}
+	il.pending[app.ID] = true
+	il.queue = append(il.queue, app)
+	if len(il.queue) > iconLoaderQueueMax {
+		delete(il.pending, il.queue[0].ID)
+		il.queue = il.queue[1:]
+	}
+	il.cond.Signal()
+}
+
+func (il *iconLoader) work() {
+	for {
+		il.mu.Lock()
+		for len(il.queue) == 0 {
+			il.cond.Wait()
+		}
+		app := il.queue[len(il.queue)-1]
+		il.queue = il.queue[:len(il.queue)-1]
+		il.mu.Unlock()
+
+		res := logic.LoadAppIcon(app)
+
+		il.mu.Lock()
+		delete(il.pending, app.ID)
+		if res == nil {
+			il.missing[app.ID] = true
+		}
+		il.mu.Unlock()
+
+		if il.onLoaded != nil {
+			il.onLoaded(app.ID)
+		}
+	}
+}
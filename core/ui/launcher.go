@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -16,10 +17,15 @@ import (
 
 	"github.com/adelylria/GoFinder/core/configuration"
 	"github.com/adelylria/GoFinder/core/global"
+	"github.com/adelylria/GoFinder/core/history"
 	"github.com/adelylria/GoFinder/core/i18n"
+	"github.com/adelylria/GoFinder/core/logger"
+	"github.com/adelylria/GoFinder/core/plugin"
+	"github.com/adelylria/GoFinder/core/provider"
 	"github.com/adelylria/GoFinder/core/resource"
 	"github.com/adelylria/GoFinder/core/singleinstance"
 	"github.com/adelylria/GoFinder/logic"
+	"github.com/adelylria/GoFinder/logic/files"
 	"github.com/adelylria/GoFinder/models"
 
 	hotkey "github.com/adelylria/GoFinder/core/hotkey"
@@ -29,17 +35,32 @@ import (
 // Ahora incorpora el ThemeConfig (core) para construir los widgets
 // con apariencia y tamaños centralizados.
 type Launcher struct {
-	window         fyne.Window
-	input          *hotkey.KeyEventInterceptor
-	list           *widget.List
-	appMap         map[string]models.Application
-	filteredIDs    []string
-	selectedIndex  int
+	window        fyne.Window
+	input         *hotkey.KeyEventInterceptor
+	list          *widget.List
+	icons         *iconLoader
+	apps          *appProvider
+	shell         *shellProvider
+	files         *fileProvider
+	providers     *provider.Manager
+	plugins       []*plugin.Plugin
+	results       []provider.Result
+	searchCancel  context.CancelFunc // cancela la búsqueda en curso; solo desde la UI
+	resultsQuery  string             // búsqueda de la que salen results
+	submitPending bool               // Enter antes de que llegaran los resultados del texto actual
+	selectedIndex int
+	// parentResults, si no es nil, es la lista de la que se entró con Tab o
+	// Right a las subentradas que muestra results; parentID, el resultado
+	// del que se entró, para volver a él aunque la lista se haya refrescado.
+	parentResults  []provider.Result
+	parentID       string
 	theme          *ThemeConfig
 	config         configuration.Config
 	startHidden    bool
 	hotkeys        *hotkey.HotkeyManager
 	dialogsMu      sync.Mutex
+	discoverMu     sync.Mutex
+	discoverCancel context.CancelFunc // cancela la búsqueda de apps en curso
 	settingsDialog dialog.Dialog
 	aboutDialog    dialog.Dialog
 	// prevContent stores the previous window content when navigating to settings
@@ -53,7 +74,9 @@ type Launcher struct {
 }
 
 // NewLauncher crea el lanzador e inyecta el theme core.
-func NewLauncher(apps []models.Application) *Launcher {
+// La lista empieza con el índice del último escaneo, si lo hay, y Run lo
+// reconcilia con un escaneo nuevo.
+func NewLauncher() *Launcher {
 	myApp := app.New()
 
 	cfg, err := configuration.Load()
@@ -73,7 +96,18 @@ func NewLauncher(apps []models.Application) *Launcher {
 	t := DefaultTheme()
 	t.ApplyToWindow(window)
 
-	appMap := createAppMap(apps)
+	indexed, err := logic.LoadAppIndex()
+	if err != nil {
+		log.Printf("Error cargando índice de aplicaciones: %v", err)
+	}
+	launchHistory, err := history.Load()
+	if err != nil {
+		log.Printf("Error cargando historial: %v", err)
+	}
+	commands, err := history.LoadCommands()
+	if err != nil {
+		log.Printf("Error cargando historial de órdenes: %v", err)
+	}
 
 	appState := &AppState{
 		Window:  window,
@@ -90,28 +124,86 @@ func NewLauncher(apps []models.Application) *Launcher {
 	singleinstance.SetActivationHandler(func() {
 		setWindowVisible(appState, true)
 	})
-	startSystemTray(appState, resource.GetEmbedAppIconBytes())
 
-	return &Launcher{
+	apps := newAppProvider(indexed, launchHistory)
+
+	l := &Launcher{
 		window:        window,
-		appMap:        appMap,
-		filteredIDs:   getAllAppIDs(appMap),
+		apps:          apps,
 		selectedIndex: 0,
 		theme:         t,
 		config:        cfg,
 		startHidden:   cfg.StartHidden,
 		hotkeys:       hm,
 	}
+	l.icons = newIconLoader(l.refreshAppRows)
+	l.shell = newShellProvider(commands, l.showShellFailure)
+	l.shell.SetTerminal(cfg.ShellInTerminal, cfg.Terminal)
+	l.files = newFileProvider(newFileIndex(cfg))
+	l.providers = provider.NewManager(apps, calcProvider{}, unitsProvider{}, l.shell, l.files, newRecentProvider(apps))
+	l.loadPlugins()
+	startSystemTray(appState, resource.GetEmbedAppIconBytes(), l.rescan)
+
+	if _, err := logic.WatchApplications(func(changes []models.AppChange) {
+		fyne.Do(func() { l.applyAppChanges(changes) })
+	}); err != nil {
+		log.Printf("No se vigilarán las aplicaciones: %v", err)
+	}
+	return l
+}
+
+// newFileIndex crea el índice de ficheros con las raíces y exclusiones de
+// la configuración.
+func newFileIndex(cfg configuration.Config) *files.Index {
+	path, err := files.IndexPath()
+	if err != nil {
+		log.Printf("No se guardará el índice de ficheros: %v", err)
+	}
+	ignore := cfg.FileIgnore
+	if len(ignore) == 0 {
+		ignore = files.DefaultIgnore
+	}
+	return files.NewIndex(cfg.FileRoots, ignore, path)
+}
+
+// loadPlugins registra un proveedor por plugin instalado y arranca los que
+// no están deshabilitados en la configuración.
+func (l *Launcher) loadPlugins() {
+	dir, err := plugin.Dir()
+	if err != nil {
+		log.Printf("Error localizando los plugins: %v", err)
+		return
+	}
+	plugins, err := plugin.Discover(dir)
+	if err != nil {
+		log.Printf("Error cargando plugins: %v", err)
+		return
+	}
+	disabled := make(map[string]bool)
+	for _, id := range l.config.DisabledPlugins {
+		disabled[id] = true
+	}
+	for _, p := range plugins {
+		p.SetEnabled(!disabled[p.ID()])
+		l.providers.Register(p)
+	}
+	l.plugins = plugins
 }
 
 // Inicia y muestra la interfaz de usuario
 func (l *Launcher) Run() {
 	l.initializeUI()
+	l.search("", false)
+	// Sin índice la lista se va llenando según llegan las apps; con índice
+	// se sustituye de una vez al terminar, sin parpadeos.
+	l.discover(len(l.apps.Apps()) == 0)
+	l.files.LoadInBackground()
 	if !l.startHidden {
 		l.window.Show()
 	}
 	l.applyNativeMenuPlatformHooks()
 	fyne.CurrentApp().Run()
+	logIconCacheStats()
 }
 
 // Configura todos los componentes de la interfaz
@@ -156,26 +248,72 @@ func (l *Launcher) createAppList() *widget.List {
 		l.getItemCount,
 		func() fyne.CanvasObject { return l.theme.CreateListItemDefault() },
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
-			// seguridad por si hay cambios en filteredIDs
-			if id >= len(l.filteredIDs) {
+			// seguridad por si hay cambios en results
+			if id >= len(l.results) {
 				// limpiar item
-				l.theme.UpdateListItemDefault(id, obj, "", nil, false)
+				l.theme.UpdateListItemDefault(id, obj, "", nil, nil, false)
 				return
 			}
-			appID := l.filteredIDs[id]
-			app := l.appMap[appID]
-			res := logic.LoadAppIcon(app)
+			result := l.results[id]
+			l.prefetchIcons(id)
 			selected := (id == l.selectedIndex)
-			l.theme.UpdateListItemDefault(id, obj, app.Name, res, selected)
+			l.theme.UpdateListItemDefault(id, obj, result.Title, result.Matches, l.resultIcon(result), selected)
 		},
 	)
 }
 
+// resultIcon devuelve el icono de la fila: el de la app, que se carga en
+// segundo plano, o el que trae el resultado.
+func (l *Launcher) resultIcon(result provider.Result) fyne.Resource {
+	if result.App != nil {
+		if res, loading := l.icons.Icon(*result.App); !loading {
+			return res
+		}
+		return l.theme.DefaultIcon
+	}
+	if result.Icon != nil {
+		return result.Icon
+	}
+	return l.theme.DefaultIcon
+}
+
+// prefetchIcons pide los iconos de las filas cercanas a la que se dibuja
+// para que ya estén en caché cuando el scroll llegue a ellas.
+func (l *Launcher) prefetchIcons(id widget.ListItemID) {
+	for offset := 1; offset <= iconPrefetchRows; offset++ {
+		for _, row := range []int{id + offset, id - offset} {
+			if row < 0 || row >= len(l.results) || l.results[row].App == nil {
+				continue
+			}
+			l.icons.Prefetch(*l.results[row].App)
+		}
+	}
+}
+
+// refreshAppRows redibuja solo las filas que muestran appID cuando llega su
+// icono desde el iconLoader.
+func (l *Launcher) refreshAppRows(appID string) {
+	fyne.Do(func() {
+		if l.list == nil {
+			return
+		}
+		for row, result := range l.results {
+			if result.App != nil && result.App.ID == appID {
+				l.list.RefreshItem(row)
+			}
+		}
+	})
+}
+
 // Configura todos los manejadores de eventos
 func (l *Launcher) setupEventHandlers() {
 	// Configurar navegación con flechas
 	l.input.OnKeyDown = l.handleKeyDown
 	l.input.OnKeyUp = l.handleKeyUp
+	l.input.OnExpand = l.expandSelected
+	l.input.OnCollapse = l.collapseResults
+	l.input.HistoryPrefix = shellPrefix
+	l.input.History = l.shell.History
 
 	// Eventos de cambio y envío
 	l.input.OnChanged = l.handleInputChange
@@ -199,13 +337,13 @@ func (l *Launcher) scheduleFocusInput() {
 // --- Funciones para el widget.List ---
 
 func (l *Launcher) getItemCount() int {
-	return len(l.filteredIDs)
+	return len(l.results)
 }
 
 // --- Handlers de eventos ---
 
 func (l *Launcher) handleKeyDown() {
-	if l.selectedIndex < len(l.filteredIDs)-1 {
+	if l.selectedIndex < len(l.results)-1 {
 		l.selectedIndex++
 		l.list.Refresh()
 		l.list.ScrollTo(l.selectedIndex)
@@ -221,19 +359,18 @@ func (l *Launcher) handleKeyUp() {
 }
 
 func (l *Launcher) handleInputChange(text string) {
-	l.filteredIDs = getFilteredIDs(text, l.appMap)
-	l.selectedIndex = 0
-	l.list.Refresh()
+	l.submitPending = false
+	l.search(text, false)
 }
 
 func (l *Launcher) handleInputSubmit(text string) {
-	l.executeSelectedApp()
+	l.submit()
 }
 
 func (l *Launcher) handleGlobalKeyEvent(ev *fyne.KeyEvent) {
 	switch ev.Name {
 	case fyne.KeyReturn, fyne.KeyEnter:
-		l.executeSelectedApp()
+		l.submit()
 	case fyne.KeyEscape:
 		l.window.Close()
 	}
@@ -251,63 +388,279 @@ func (l *Launcher) handleListSelection(id widget.ListItemID) {
 
 // --- Funciones de lógica de aplicación ---
 
-func (l *Launcher) executeSelectedApp() {
-	if len(l.filteredIDs) == 0 {
+// submit ejecuta el resultado seleccionado solo si la lista corresponde al
+// texto actual: con resultados de una búsqueda anterior, Enter podría lanzar
+// otra cosa (por ejemplo, una orden > más corta). Si no, espera a que
+// lleguen los del texto actual.
+func (l *Launcher) submit() {
+	if l.input != nil && l.resultsQuery != l.input.Text {
+		l.submitPending = true
 		return
 	}
+	l.executeSelectedApp()
+}
 
-	if l.selectedIndex >= len(l.filteredIDs) {
-		l.selectedIndex = len(l.filteredIDs) - 1
+// executeSelectedApp ejecuta la acción por defecto del resultado
+// seleccionado.
+func (l *Launcher) executeSelectedApp() {
+	if len(l.results) == 0 {
+		return
 	}
 
-	appID := l.filteredIDs[l.selectedIndex]
-	app := l.appMap[appID]
-	log.Printf(i18n.T(i18n.LogRunningApp), app.Name, app.Exec)
+	if l.selectedIndex >= len(l.results) {
+		l.selectedIndex = len(l.results) - 1
+	}
 
-	if err := logic.RunApplication(app); err != nil {
-		log.Printf(i18n.T(i18n.LogRunAppError), app.Name, err)
+	result := l.results[l.selectedIndex]
+	if len(result.Actions) == 0 {
+		return
+	}
+	if err := result.Actions[0].Run(); err != nil {
+		log.Printf(i18n.T(i18n.LogRunAppError), result.Title, err)
 	}
 
 	l.clearList()
 }
 
+// subResults devuelve las filas a las que se entra desde r: sus Children o,
+// si no tiene, una por acción cuando hay más de una.
+func subResults(r provider.Result) []provider.Result {
+	if len(r.Children) > 0 {
+		return r.Children
+	}
+	if len(r.Actions) < 2 {
+		return nil
+	}
+	subs := make([]provider.Result, 0, len(r.Actions))
+	for _, action := range r.Actions {
+		subs = append(subs, provider.Result{
+			ID:      r.ID + "#" + action.ID,
+			Title:   action.Title,
+			Icon:    r.Icon,
+			App:     r.App,
+			Actions: []provider.Action{action},
+		})
+	}
+	return subs
+}
+
+// expandSelected muestra las subentradas del resultado seleccionado.
+func (l *Launcher) expandSelected() bool {
+	if l.parentResults != nil || l.selectedIndex >= len(l.results) {
+		return false
+	}
+	subs := subResults(l.results[l.selectedIndex])
+	if len(subs) == 0 {
+		return false
+	}
+	l.parentResults, l.parentID = l.results, l.results[l.selectedIndex].ID
+	l.results, l.selectedIndex = subs, 0
+	l.list.Refresh()
+	l.list.ScrollTo(0)
+	return true
+}
+
+// collapseResults vuelve de las subentradas a la lista de la que se entró.
+func (l *Launcher) collapseResults() bool {
+	if l.parentResults == nil {
+		return false
+	}
+	l.results, l.selectedIndex = l.parentResults, indexOfResult(l.parentResults, l.parentID)
+	l.parentResults = nil
+	l.list.Refresh()
+	l.list.ScrollTo(l.selectedIndex)
+	return true
+}
+
 // --- Funciones auxiliares ---
 
-func createAppMap(apps []models.Application) map[string]models.Application {
-	appMap := make(map[string]models.Application)
-	for _, app := range apps {
-		appMap[app.ID] = app
+// showShellFailure vuelve a poner en la búsqueda una orden que ha fallado,
+// para que se vea su error, salvo que ya se esté buscando otra cosa.
+func (l *Launcher) showShellFailure(command string) {
+	fyne.Do(func() {
+		if l.input == nil {
+			return
+		}
+		if text := l.input.Text; text != "" && !strings.HasPrefix(text, shellPrefix) {
+			return
+		}
+		l.input.SetText(shellPrefix + " " + command)
+	})
+}
+
+// applyAppChanges actualiza en el sitio las apps que cambiaron en disco.
+// Debe llamarse desde el hilo de la UI.
+func (l *Launcher) applyAppChanges(changes []models.AppChange) {
+	l.apps.ApplyChanges(changes)
+	for _, change := range changes {
+		l.icons.Forget(change.ID)
+	}
+	l.refreshResults()
+	l.saveAppIndex()
+}
+
+// saveAppIndex guarda en segundo plano una copia de las apps actuales.
+func (l *Launcher) saveAppIndex() {
+	apps := l.apps.Apps()
+	logger.GoSafe(func() {
+		if err := logic.SaveAppIndex(apps); err != nil {
+			log.Printf("Error guardando índice de aplicaciones: %v", err)
+		}
+	})
+}
+
+// discoverFlushInterval es cada cuánto se vuelcan a la lista las apps que
+// van llegando durante la primera búsqueda.
+const discoverFlushInterval = 100 * time.Millisecond
+
+// rescan vuelve a descubrir todas las aplicaciones en segundo plano y
+// sustituye la lista al terminar, para que no desaparezcan apps a medias.
+func (l *Launcher) rescan() {
+	l.discover(false)
+}
+
+// discover busca las apps en segundo plano, cancelando la búsqueda anterior
+// si la hay. Con progressive, las apps se añaden a la lista por lotes según
+// llegan; en cualquier caso, al terminar la lista pasa a ser exactamente lo
+// encontrado.
+func (l *Launcher) discover(progressive bool) {
+	ctx, cancel := context.WithCancel(context.Background())
+	l.discoverMu.Lock()
+	if l.discoverCancel != nil {
+		l.discoverCancel()
+	}
+	l.discoverCancel = cancel
+	l.discoverMu.Unlock()
+
+	logger.GoSafe(func() {
+		var apps, batch []models.Application
+		lastFlush := time.Now()
+		err := logic.FindApplications(ctx, func(app models.Application) {
+			apps = append(apps, app)
+			if !progressive {
+				return
+			}
+			batch = append(batch, app)
+			if time.Since(lastFlush) >= discoverFlushInterval {
+				l.addApps(ctx, batch)
+				batch, lastFlush = nil, time.Now()
+			}
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Error buscando aplicaciones: %v", err)
+			}
+			return
+		}
+
+		migrateHistoryKeys(l.apps.history, apps)
+		if err := logic.SaveAppIndex(apps); err != nil {
+			log.Printf("Error guardando índice de aplicaciones: %v", err)
+		}
+		fyne.Do(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			l.apps.SetApps(apps)
+			for _, app := range apps {
+				l.icons.Forget(app.ID)
+			}
+			l.apps.refreshFrecency()
+			l.refreshResults()
+		})
+	})
+}
+
+func (l *Launcher) addApps(ctx context.Context, apps []models.Application) {
+	fyne.Do(func() {
+		if ctx.Err() != nil {
+			return
+		}
+		l.apps.AddApps(apps)
+		l.refreshResults()
+	})
+}
+
+// refreshResults repite la búsqueda actual conservando el resultado
+// seleccionado si sigue en la lista.
+func (l *Launcher) refreshResults() {
+	query := ""
+	if l.input != nil {
+		query = l.input.Text
 	}
-	return appMap
+	l.search(query, true)
 }
 
-func getAllAppIDs(appMap map[string]models.Application) []string {
-	ids := make([]string, 0, len(appMap))
-	for id := range appMap {
-		ids = append(ids, id)
+// search consulta a los proveedores fuera del hilo de la UI y muestra los
+// resultados solo si para entonces no ha empezado otra búsqueda. Debe
+// llamarse desde el hilo de la UI.
+func (l *Launcher) search(query string, keepSelection bool) {
+	if l.searchCancel != nil {
+		l.searchCancel()
 	}
-	return ids
+	ctx, cancel := context.WithCancel(context.Background())
+	l.searchCancel = cancel
+
+	logger.GoSafe(func() {
+		results := l.providers.Query(ctx, query)
+		fyne.Do(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			cancel()
+			l.showResults(query, results, keepSelection)
+			if l.submitPending && l.input != nil && query == l.input.Text {
+				l.submitPending = false
+				l.executeSelectedApp()
+			}
+		})
+	})
 }
 
-func getFilteredIDs(filter string, appMap map[string]models.Application) []string {
-	if filter == "" {
-		return getAllAppIDs(appMap)
+func (l *Launcher) showResults(query string, results []provider.Result, keepSelection bool) {
+	l.resultsQuery = query
+	if l.parentResults != nil {
+		if keepSelection {
+			// Un refresco en segundo plano no saca de las subentradas: se
+			// actualiza la lista a la que se volverá.
+			l.parentResults = results
+			return
+		}
+		l.parentResults = nil
 	}
 
-	var ids []string
-	lowerFilter := strings.ToLower(filter)
+	var selectedID string
+	if keepSelection && l.selectedIndex >= 0 && l.selectedIndex < len(l.results) {
+		selectedID = l.results[l.selectedIndex].ID
+	}
+
+	l.results = results
+	l.selectedIndex = 0
+	for i, result := range l.results {
+		if selectedID != "" && result.ID == selectedID {
+			l.selectedIndex = i
+			break
+		}
+	}
+	if l.list != nil {
+		l.list.Refresh()
+	}
+}
 
-	for id, app := range appMap {
-		if strings.Contains(strings.ToLower(app.Name), lowerFilter) {
-			ids = append(ids, id)
+// indexOfResult devuelve la fila del resultado con ese ID, o 0 si no está.
+func indexOfResult(results []provider.Result, id string) int {
+	for i, result := range results {
+		if result.ID == id {
+			return i
 		}
 	}
-	return ids
+	return 0
 }
 
 func (l *Launcher) clearList() {
 	l.input.SetText("")
-	l.filteredIDs = getAllAppIDs(l.appMap)
+	// SetText no avisa si ya estaba vacío, y el historial acaba de cambiar.
+	l.search("", false)
 
 	go fyne.Do(func() {
 		time.Sleep(global.UIInteractionDelay)
@@ -319,8 +672,8 @@ func (l *Launcher) clearList() {
 }
 
 // Punto de entrada para iniciar el lanzador
-func RunLauncher(apps []models.Application) {
-	launcher := NewLauncher(apps)
+func RunLauncher() {
+	launcher := NewLauncher()
 	launcher.Run()
 }
 
@@ -351,7 +704,16 @@ func setWindowVisible(state *AppState, visible bool) {
 	})
 }
 
+// logIconCacheStats deja constancia al salir de si el tamaño de la caché de
+// iconos basta.
+func logIconCacheStats() {
+	log.Print(logic.IconCacheStats())
+}
+
+// quitApplication es la salida del menú, el atajo y la bandeja; cerrar la
+// ventana termina en cambio al volver Run.
 func quitApplication() {
+	logIconCacheStats()
 	fmt.Println(i18n.T(i18n.AppExitMessage))
 	singleinstance.Release()
 	os.Exit(0)
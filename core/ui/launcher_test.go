@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/adelylria/GoFinder/core/provider"
+)
+
+func TestShowResultsKeepsSubResults(t *testing.T) {
+	parent := []provider.Result{{ID: "app:a"}, {ID: "app:b"}}
+	subs := []provider.Result{{ID: "app:b#new-window"}}
+	l := &Launcher{results: subs, parentResults: parent, parentID: "app:b"}
+
+	// El primer refresco no encuentra nada; el segundo no debe fallar.
+	l.showResults("b", make([]provider.Result, 0), true)
+	l.showResults("b", []provider.Result{{ID: "app:c"}, {ID: "app:b"}}, true)
+
+	if len(l.results) != 1 || l.results[0].ID != "app:b#new-window" {
+		t.Fatalf("results = %+v, se esperaban las subentradas", l.results)
+	}
+	if got := indexOfResult(l.parentResults, l.parentID); got != 1 {
+		t.Fatalf("fila del padre = %d, se esperaba 1", got)
+	}
+}
@@ -18,6 +18,11 @@ func (l *Launcher) setupNativeMainMenu() {
 		Modifier: fyne.KeyModifierControl,
 	}
 
+	rescanItem := fyne.NewMenuItem(i18n.T(i18n.MenuRescan), l.rescan)
+	rescanItem.Shortcut = &desktop.CustomShortcut{
+		KeyName: fyne.KeyF5,
+	}
+
 	prefItem := fyne.NewMenuItem(i18n.T(i18n.MenuPreferences), l.showSettingsDialog)
 	prefItem.Shortcut = &desktop.CustomShortcut{
 		KeyName:  fyne.KeyComma,
@@ -29,7 +34,7 @@ func (l *Launcher) setupNativeMainMenu() {
 		KeyName: fyne.KeyF1,
 	}
 
-	fileMenu := fyne.NewMenu(i18n.T(i18n.MenuFile), exitItem)
+	fileMenu := fyne.NewMenu(i18n.T(i18n.MenuFile), rescanItem, fyne.NewMenuItemSeparator(), exitItem)
 	configMenu := fyne.NewMenu(i18n.T(i18n.MenuConfig), prefItem)
 	helpMenu := fyne.NewMenu(i18n.T(i18n.MenuHelp), aboutItem)
 
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adelylria/GoFinder/core/fuzzy"
+	"github.com/adelylria/GoFinder/core/i18n"
+	"github.com/adelylria/GoFinder/core/provider"
+	"github.com/adelylria/GoFinder/logic"
+	"github.com/adelylria/GoFinder/models"
+)
+
+const (
+	// recentPriority deja los documentos recientes por debajo de las apps.
+	recentPriority   = -10
+	maxRecentResults = 5
+)
+
+// recentProvider busca por nombre en los documentos abiertos hace poco. Si
+// la app que abrió cada uno está instalada, Enter lo abre con ella.
+type recentProvider struct {
+	load    func() ([]models.RecentFile, error)
+	findApp func(name string) (models.Application, bool)
+	now     func() time.Time
+}
+
+func newRecentProvider(apps *appProvider) *recentProvider {
+	return &recentProvider{load: logic.RecentFiles, findApp: apps.FindByDesktopName, now: time.Now}
+}
+
+func (p *recentProvider) Info() provider.Info {
+	return provider.Info{Name: "recent", Priority: recentPriority}
+}
+
+type rankedRecent struct {
+	file  models.RecentFile
+	name  string
+	score int
+}
+
+func (p *recentProvider) Query(ctx context.Context, query string) ([]provider.Result, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil
+	}
+	recent, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+
+	matcher := fuzzy.NewMatcher(query)
+	var ranked []rankedRecent
+	for _, file := range recent {
+		name := filepath.Base(file.Path)
+		if m, ok := matcher.Score(name); ok {
+			ranked = append(ranked, rankedRecent{file, name, m.Score})
+		}
+	}
+	// A igual puntuación se mantiene el orden del registro, el más reciente
+	// primero.
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+
+	now := p.now()
+	var results []provider.Result
+	for _, r := range ranked {
+		if len(results) == maxRecentResults || ctx.Err() != nil {
+			break
+		}
+		// El registro puede ser anterior a que se borrara el fichero.
+		if _, err := os.Stat(r.file.Path); err != nil {
+			continue
+		}
+		results = append(results, p.result(r, matcher, now))
+	}
+	return results, ctx.Err()
+}
+
+// result muestra tras el nombre qué app abrió el fichero y cuándo.
+func (p *recentProvider) result(r rankedRecent, matcher *fuzzy.Matcher, now time.Time) provider.Result {
+	path := r.file.Path
+	open := provider.Action{
+		ID:    "open",
+		Title: i18n.T(i18n.ActionOpen),
+		Run:   func() error { return logic.OpenPath(path) },
+	}
+	appName := r.file.AppName
+	if app, ok := p.findApp(r.file.AppName); ok {
+		appName = app.Name
+		open.Title = fmt.Sprintf(i18n.T(i18n.ActionOpenWith), app.Name)
+		open.Run = func() error { return logic.OpenPathWith(app, path) }
+	}
+
+	var details []string
+	if appName != "" {
+		details = append(details, appName)
+	}
+	if when := relativeTime(now, r.file.Modified); when != "" {
+		details = append(details, when)
+	}
+	title := r.name
+	if len(details) > 0 {
+		title += " — " + strings.Join(details, ", ")
+	}
+
+	return provider.Result{
+		ID:       "recent:" + path,
+		Title:    title,
+		Subtitle: path,
+		Icon:     fileIcon(r.name, false),
+		Score:    r.score,
+		Matches:  matcher.Ranges(r.name),
+		Actions: []provider.Action{
+			open,
+			{ID: "reveal", Title: i18n.T(i18n.ActionReveal), Run: func() error { return logic.RevealPath(path) }},
+		},
+	}
+}
+
+// relativeTime describe cuánto hace de t; vacío si no se conoce.
+func relativeTime(now, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	switch d := now.Sub(t); {
+	case d < time.Minute:
+		return i18n.T(i18n.RecentJustNow)
+	case d < time.Hour:
+		return fmt.Sprintf(i18n.T(i18n.RecentMinutes), int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf(i18n.T(i18n.RecentHours), int(d/time.Hour))
+	default:
+		return fmt.Sprintf(i18n.T(i18n.RecentDays), int(d/(24*time.Hour)))
+	}
+}
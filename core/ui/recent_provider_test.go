@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/adelylria/GoFinder/core/i18n"
+	"github.com/adelylria/GoFinder/models"
+)
+
+func TestRecentProviderQuery(t *testing.T) {
+	defer i18n.SetLanguage(i18n.CurrentLanguage())
+	i18n.SetLanguage(i18n.English)
+	dir := t.TempDir()
+	report := filepath.Join(dir, "report.pdf")
+	notes := filepath.Join(dir, "report-notes.txt")
+	for _, path := range []string{report, notes} {
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)
+	evince := models.Application{ID: "desktop:org.gnome.Evince.desktop", Name: "Document Viewer", DesktopID: "org.gnome.Evince.desktop"}
+	p := &recentProvider{
+		load: func() ([]models.RecentFile, error) {
+			return []models.RecentFile{
+				{Path: filepath.Join(dir, "report-old.txt"), AppName: "gedit", Modified: now.Add(-time.Minute)},
+				{Path: notes, AppName: "gedit", Modified: now.Add(-3 * time.Hour)},
+				{Path: report, AppName: "org.gnome.Evince", Modified: now.Add(-2 * 24 * time.Hour)},
+			}, nil
+		},
+		findApp: newAppProvider([]models.Application{evince}, nil).FindByDesktopName,
+		now:     func() time.Time { return now },
+	}
+
+	results, err := p.Query(context.Background(), "report")
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	var titles, actions []string
+	for _, r := range results {
+		titles = append(titles, r.Title)
+		actions = append(actions, r.Actions[0].Title)
+	}
+	// El fichero borrado no sale, y el nombre que mejor encaja va antes
+	// aunque se abriera hace más tiempo.
+	want := []string{"report.pdf — Document Viewer, 2 d ago", "report-notes.txt — gedit, 3 h ago"}
+	if !reflect.DeepEqual(titles, want) {
+		t.Fatalf("Query(report) = %q, want %q", titles, want)
+	}
+	if want := []string{"Open with Document Viewer", "Open"}; !reflect.DeepEqual(actions, want) {
+		t.Fatalf("acciones = %q, want %q", actions, want)
+	}
+}
@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"strings"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/widget"
@@ -88,9 +90,41 @@ func (l *Launcher) generalSection(initializing *bool) fyne.CanvasObject {
 	})
 	startHidden.SetChecked(l.config.StartHidden)
 
+	shellTerminal := widget.NewCheck(i18n.T(i18n.SettingsShellTerm), func(value bool) {
+		if *initializing {
+			return
+		}
+		l.config.ShellInTerminal = value
+		l.shell.SetTerminal(value, l.config.Terminal)
+		l.saveSettings(i18n.T(i18n.SettingsShellSaved))
+	})
+	shellTerminal.SetChecked(l.config.ShellInTerminal)
+
+	// El emulador se guarda con Enter para no escribir la configuración en
+	// cada pulsación.
+	terminal := widget.NewEntry()
+	terminal.SetPlaceHolder(i18n.T(i18n.SettingsShellEmuHint))
+	terminal.SetText(l.config.Terminal)
+	terminal.OnSubmitted = func(value string) {
+		l.config.Terminal = strings.TrimSpace(value)
+		l.shell.SetTerminal(l.config.ShellInTerminal, l.config.Terminal)
+		l.saveSettings(i18n.T(i18n.SettingsShellSaved))
+	}
+
+	clearHistory := widget.NewButton(i18n.T(i18n.SettingsHistoryClear), func() {
+		if err := l.apps.clearHistory(); err != nil {
+			l.showSettingsToast(err.Error())
+			return
+		}
+		l.showSettingsToast(i18n.T(i18n.SettingsHistoryClean))
+	})
+
 	return container.NewVBox(
 		widget.NewLabelWithStyle(i18n.T(i18n.SettingsGeneral), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		autoStart,
 		startHidden,
+		shellTerminal,
+		container.NewGridWithColumns(2, widget.NewLabel(i18n.T(i18n.SettingsShellEmu)), terminal),
+		container.NewGridWithColumns(2, widget.NewLabel(i18n.T(i18n.SettingsHistory)), clearHistory),
 	)
 }
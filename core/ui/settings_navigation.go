@@ -26,6 +26,12 @@ func (l *Launcher) showSettingsHome() {
 			theme.SettingsIcon(),
 			l.showConfigurationSettings,
 		),
+		l.settingsNavCard(
+			i18n.T(i18n.SettingsPlugins),
+			i18n.T(i18n.SettingsPluginsDesc),
+			theme.ComputerIcon(),
+			l.showPluginSettings,
+		),
 	)
 
 	l.setSettingsRootContent(i18n.T(i18n.MenuPreferences), body)
@@ -56,6 +62,14 @@ func (l *Launcher) showConfigurationSettings() {
 	)
 }
 
+func (l *Launcher) showPluginSettings() {
+	l.setSettingsContent(
+		i18n.T(i18n.SettingsPlugins),
+		l.showSettingsHome,
+		l.pluginsSection(),
+	)
+}
+
 func (l *Launcher) setSettingsContent(title string, onBack func(), body fyne.CanvasObject) {
 	l.setSettingsContentWithIcon(title, theme.NavigateBackIcon(), onBack, body)
 }
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/adelylria/GoFinder/core/i18n"
+	"github.com/adelylria/GoFinder/core/plugin"
+)
+
+// pluginsSection lists the installed plugins with an enable toggle each.
+func (l *Launcher) pluginsSection() fyne.CanvasObject {
+	dir, _ := plugin.Dir()
+	help := widget.NewLabel(fmt.Sprintf(i18n.T(i18n.SettingsPluginsDir), dir))
+	help.Wrapping = fyne.TextWrapWord
+
+	rows := container.NewVBox(help)
+	if len(l.plugins) == 0 {
+		rows.Add(widget.NewLabel(i18n.T(i18n.SettingsPluginsEmpty)))
+		return rows
+	}
+
+	for _, p := range l.plugins {
+		label := p.Info().Name
+		if label != p.ID() {
+			label = fmt.Sprintf("%s (%s)", label, p.ID())
+		}
+		check := widget.NewCheck(label, nil)
+		check.SetChecked(p.Enabled())
+		check.OnChanged = func(enabled bool) {
+			l.setPluginEnabled(p, enabled)
+		}
+		rows.Add(check)
+	}
+	return rows
+}
+
+func (l *Launcher) setPluginEnabled(p *plugin.Plugin, enabled bool) {
+	p.SetEnabled(enabled)
+
+	var disabled []string
+	for _, id := range l.config.DisabledPlugins {
+		if id != p.ID() {
+			disabled = append(disabled, id)
+		}
+	}
+	if !enabled {
+		disabled = append(disabled, p.ID())
+	}
+	l.config.DisabledPlugins = disabled
+	l.saveSettings(i18n.T(i18n.SettingsPluginsSaved))
+}
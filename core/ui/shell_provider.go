@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2/theme"
+
+	"github.com/adelylria/GoFinder/core/history"
+	"github.com/adelylria/GoFinder/core/i18n"
+	"github.com/adelylria/GoFinder/core/logger"
+	"github.com/adelylria/GoFinder/core/provider"
+	"github.com/adelylria/GoFinder/logic"
+)
+
+// shellPrefix activa el modo de órdenes: el resto de la búsqueda se ejecuta
+// con el shell del sistema.
+const shellPrefix = ">"
+
+// shellProvider muestra la orden escrita tras ">" y la ejecuta con Enter,
+// en segundo plano o en un terminal. Si falla, onFail recibe la orden para
+// volver a mostrarla con el error debajo.
+type shellProvider struct {
+	commands *history.Commands
+	onFail   func(command string) // se llama desde cualquier goroutine
+
+	mu         sync.Mutex
+	inTerminal bool
+	terminal   string
+	failed     string // orden que falló la última vez
+	failure    string // su error, tal como se muestra
+}
+
+func newShellProvider(commands *history.Commands, onFail func(command string)) *shellProvider {
+	return &shellProvider{commands: commands, onFail: onFail}
+}
+
+func (p *shellProvider) Info() provider.Info {
+	return provider.Info{Name: "shell", Prefix: shellPrefix}
+}
+
+// SetTerminal elige si Enter abre la orden en un terminal y con cuál.
+func (p *shellProvider) SetTerminal(inTerminal bool, terminal string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inTerminal, p.terminal = inTerminal, terminal
+}
+
+func (p *shellProvider) Query(_ context.Context, query string) ([]provider.Result, error) {
+	command := strings.TrimSpace(query)
+	if command == "" {
+		return []provider.Result{{
+			ID:    "shell",
+			Title: i18n.T(i18n.ShellHint),
+			Icon:  theme.ComputerIcon(),
+		}}, nil
+	}
+
+	p.mu.Lock()
+	inTerminal := p.inTerminal
+	failure := ""
+	if p.failed == command {
+		failure = p.failure
+	}
+	p.mu.Unlock()
+
+	run := provider.Action{
+		ID:    "run",
+		Title: i18n.T(i18n.ActionRun),
+		Run:   func() error { return p.run(command) },
+	}
+	runTerminal := provider.Action{
+		ID:    "terminal",
+		Title: i18n.T(i18n.ActionRunTerminal),
+		Run:   func() error { return p.runInTerminal(command) },
+	}
+	actions := []provider.Action{run, runTerminal}
+	if inTerminal {
+		actions = []provider.Action{runTerminal, run}
+	}
+
+	results := []provider.Result{{
+		ID:      "shell",
+		Title:   fmt.Sprintf(i18n.T(i18n.ShellRun), command),
+		Icon:    theme.ComputerIcon(),
+		Actions: actions,
+	}}
+	if failure != "" {
+		results = append(results, provider.Result{
+			ID:    "shell:error",
+			Title: failure,
+			Icon:  theme.ErrorIcon(),
+		})
+	}
+	return results, nil
+}
+
+// History devuelve las órdenes anteriores como texto de búsqueda, para
+// recorrerlas con Up/Down.
+func (p *shellProvider) History() []string {
+	commands := p.commands.List()
+	for i, command := range commands {
+		commands[i] = shellPrefix + " " + command
+	}
+	return commands
+}
+
+// run lanza la orden sin terminal y vigila en segundo plano cómo termina.
+func (p *shellProvider) run(command string) error {
+	p.started(command)
+	logger.GoSafe(func() {
+		if err := logic.RunCommand(command); err != nil {
+			p.fail(command, err)
+		}
+	})
+	return nil
+}
+
+func (p *shellProvider) runInTerminal(command string) error {
+	p.started(command)
+	p.mu.Lock()
+	terminal := p.terminal
+	p.mu.Unlock()
+	if err := logic.RunCommandInTerminal(command, terminal); err != nil {
+		p.fail(command, err)
+	}
+	return nil
+}
+
+func (p *shellProvider) started(command string) {
+	log.Printf(i18n.T(i18n.LogRunningApp), command, shellPrefix)
+	p.mu.Lock()
+	p.failed, p.failure = "", ""
+	p.mu.Unlock()
+	if err := p.commands.Add(command); err != nil {
+		log.Printf("Error guardando el historial de órdenes: %v", err)
+	}
+}
+
+func (p *shellProvider) fail(command string, err error) {
+	log.Printf("La orden %q falló: %v", command, err)
+
+	message := fmt.Sprintf(i18n.T(i18n.ShellError), err)
+	var cmdErr *logic.CommandError
+	if errors.As(err, &cmdErr) {
+		message = fmt.Sprintf(i18n.T(i18n.ShellExitStatus), cmdErr.ExitCode)
+		if line := cmdErr.LastLine(); line != "" {
+			message += ": " + line
+		}
+	}
+
+	p.mu.Lock()
+	p.failed, p.failure = command, message
+	p.mu.Unlock()
+	if p.onFail != nil {
+		p.onFail(command)
+	}
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/adelylria/GoFinder/core/history"
+	"github.com/adelylria/GoFinder/core/i18n"
+)
+
+func TestShellProviderFailure(t *testing.T) {
+	defer i18n.SetLanguage(i18n.CurrentLanguage())
+	i18n.SetLanguage(i18n.English)
+
+	commands, err := history.LoadCommandsFile(filepath.Join(t.TempDir(), "commands.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed := make(chan string, 1)
+	p := newShellProvider(commands, func(command string) { failed <- command })
+
+	results, _ := p.Query(context.Background(), " exit 3 ")
+	if len(results) != 1 || results[0].Title != "Run: exit 3" {
+		t.Fatalf("Query = %+v", results)
+	}
+	if err := results[0].Actions[0].Run(); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	select {
+	case command := <-failed:
+		if command != "exit 3" {
+			t.Fatalf("onFail(%q)", command)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("la orden no falló")
+	}
+
+	results, _ = p.Query(context.Background(), "exit 3")
+	if len(results) != 2 || results[1].Title != "Exit status 3" {
+		t.Fatalf("tras fallar: %+v", results)
+	}
+	if got := p.History(); !reflect.DeepEqual(got, []string{"> exit 3"}) {
+		t.Fatalf("History() = %q", got)
+	}
+}
@@ -2,6 +2,6 @@
 
 package ui
 
-func startSystemTray(state *AppState, icon []byte) {
+func startSystemTray(state *AppState, icon []byte, rescan func()) {
 	// System tray is not implemented for non-Windows platforms in this version.
 }
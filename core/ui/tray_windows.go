@@ -12,13 +12,13 @@ import (
 
 var trayOnce sync.Once
 
-func startSystemTray(state *AppState, icon []byte) {
+func startSystemTray(state *AppState, icon []byte, rescan func()) {
 	if !singleinstance.IsOwner() {
 		return
 	}
 	trayOnce.Do(func() {
 		go systray.Run(
-			func() { setupSystemTray(state, icon) },
+			func() { setupSystemTray(state, icon, rescan) },
 			func() {
 				// Cleanup code when the system tray is exited can be added here if needed.
 			},
@@ -26,7 +26,7 @@ func startSystemTray(state *AppState, icon []byte) {
 	})
 }
 
-func setupSystemTray(state *AppState, icon []byte) {
+func setupSystemTray(state *AppState, icon []byte, rescan func()) {
 	if len(icon) > 0 {
 		systray.SetIcon(icon)
 	}
@@ -34,6 +34,7 @@ func setupSystemTray(state *AppState, icon []byte) {
 
 	toggleItem := systray.AddMenuItem(i18n.T(i18n.TrayToggleTitle), i18n.T(i18n.TrayToggleTooltip))
 	minimizeItem := systray.AddMenuItem(i18n.T(i18n.TrayMinimizeTitle), i18n.T(i18n.TrayMinimizeTip))
+	rescanItem := systray.AddMenuItem(i18n.T(i18n.TrayRescanTitle), i18n.T(i18n.TrayRescanTooltip))
 	systray.AddSeparator()
 	quitItem := systray.AddMenuItem(i18n.T(i18n.TrayQuitTitle), i18n.T(i18n.TrayQuitTooltip))
 
@@ -44,6 +45,8 @@ func setupSystemTray(state *AppState, icon []byte) {
 				toggleWindowVisibility(state)
 			case <-minimizeItem.ClickedCh:
 				setWindowVisible(state, false)
+			case <-rescanItem.ClickedCh:
+				rescan()
 			case <-quitItem.ClickedCh:
 				systray.Quit()
 				quitApplication()
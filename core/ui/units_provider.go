@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"context"
+	"strings"
+
+	"fyne.io/fyne/v2/theme"
+
+	"github.com/adelylria/GoFinder/core/i18n"
+	"github.com/adelylria/GoFinder/core/provider"
+	"github.com/adelylria/GoFinder/core/units"
+)
+
+// unitsProvider muestra la conversión cuando la búsqueda es del tipo
+// "10 km in mi"; Enter copia el valor convertido.
+type unitsProvider struct{}
+
+func (unitsProvider) Info() provider.Info {
+	return provider.Info{Name: "units", Priority: calcPriority}
+}
+
+func (unitsProvider) Query(_ context.Context, query string) ([]provider.Result, error) {
+	conv, err := units.Convert(query)
+	if err != nil {
+		return nil, nil
+	}
+	value := conv.String()
+	return []provider.Result{{
+		ID:       "units",
+		Title:    "= " + value + " " + conv.To.Symbol,
+		Subtitle: strings.TrimSpace(query),
+		Icon:     theme.ContentCopyIcon(),
+		Actions: []provider.Action{{
+			ID:    "copy",
+			Title: i18n.T(i18n.ActionCopy),
+			Run:   func() error { return copyToClipboard(value) },
+		}},
+	}}, nil
+}
@@ -8,6 +8,7 @@ import (
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"github.com/adelylria/GoFinder/core/fuzzy"
 	"github.com/adelylria/GoFinder/core/hotkey"
 	"github.com/adelylria/GoFinder/core/i18n"
 )
@@ -22,14 +23,15 @@ import (
 
 // ThemeConfig agrupa todas las métricas visuales y colores usados por la UI.
 type ThemeConfig struct {
-	WindowSize       fyne.Size   // tamaño por defecto de la ventana
-	FixedWindow      bool        // si fijar el tamaño de la ventana
-	InputHeight      float32     // altura del campo de búsqueda
-	InputPlaceholder string      // placeholder por defecto para el entry
-	ListItemHeight   float32     // altura de cada item en la lista
-	Padding          float32     // padding general
-	CornerRadius     float32     // radio de esquinas para rectángulos decorativos
-	HighlightColor   color.Color // color de selección / resaltado
+	WindowSize       fyne.Size           // tamaño por defecto de la ventana
+	FixedWindow      bool                // si fijar el tamaño de la ventana
+	InputHeight      float32             // altura del campo de búsqueda
+	InputPlaceholder string              // placeholder por defecto para el entry
+	ListItemHeight   float32             // altura de cada item en la lista
+	Padding          float32             // padding general
+	CornerRadius     float32             // radio de esquinas para rectángulos decorativos
+	HighlightColor   color.Color         // color de selección / resaltado
+	MatchColor       fyne.ThemeColorName // color de las letras que coinciden con la búsqueda
 	DefaultIcon      fyne.Resource
 }
 
@@ -45,6 +47,8 @@ func DefaultTheme() *ThemeConfig {
 		Padding:          8,
 		CornerRadius:     6,
 		HighlightColor:   theme.Color(theme.ColorNameHover),
+		MatchColor:       theme.ColorNamePrimary,
+		DefaultIcon:      theme.FileApplicationIcon(),
 	}
 }
 
@@ -89,8 +93,7 @@ func (t *ThemeConfig) CreateListItemDefault() fyne.CanvasObject {
 
 	icon := widget.NewIcon(nil)
 
-	label := widget.NewLabel("")
-	label.TextStyle = fyne.TextStyle{Bold: true}
+	label := widget.NewRichText()
 
 	content := container.NewHBox(icon, container.NewVBox(label))
 
@@ -102,7 +105,8 @@ func (t *ThemeConfig) CreateListItemDefault() fyne.CanvasObject {
 
 // UpdateListItemDefault actualiza los elementos típicos de un item creado con CreateListItemDefault.
 // Se espera que obj sea el Container devuelto por CreateListItemDefault.
-func (t *ThemeConfig) UpdateListItemDefault(id widget.ListItemID, obj fyne.CanvasObject, name string, iconRes fyne.Resource, selected bool) {
+// matches son los tramos de name que coinciden con la búsqueda.
+func (t *ThemeConfig) UpdateListItemDefault(id widget.ListItemID, obj fyne.CanvasObject, name string, matches []fuzzy.Range, iconRes fyne.Resource, selected bool) {
 	stack, ok := obj.(*fyne.Container)
 	if !ok || len(stack.Objects) < 2 {
 		return
@@ -118,7 +122,7 @@ func (t *ThemeConfig) UpdateListItemDefault(id widget.ListItemID, obj fyne.Canva
 		return
 	}
 
-	t.updateListItemContent(content, name, iconRes)
+	t.updateListItemContent(content, name, matches, iconRes)
 
 	if selected {
 		bg.Show()
@@ -130,7 +134,7 @@ func (t *ThemeConfig) UpdateListItemDefault(id widget.ListItemID, obj fyne.Canva
 
 // updateListItemContent actualiza el icono y la etiqueta dentro del contenedor de contenido
 // del elemento de la lista. Extraído a helper para reducir la complejidad cognitiva.
-func (t *ThemeConfig) updateListItemContent(content *fyne.Container, name string, iconRes fyne.Resource) {
+func (t *ThemeConfig) updateListItemContent(content *fyne.Container, name string, matches []fuzzy.Range, iconRes fyne.Resource) {
 	if len(content.Objects) == 0 {
 		return
 	}
@@ -151,11 +155,40 @@ func (t *ThemeConfig) updateListItemContent(content *fyne.Container, name string
 	if !ok || len(vbox.Objects) == 0 {
 		return
 	}
-	if label, ok := vbox.Objects[0].(*widget.Label); ok {
-		label.SetText(name)
+	if label, ok := vbox.Objects[0].(*widget.RichText); ok {
+		label.Segments = t.nameSegments(name, matches)
+		label.Refresh()
 	}
 }
 
+// nameSegments parte el nombre en tramos en negrita, coloreando con
+// MatchColor los que coinciden con la búsqueda.
+func (t *ThemeConfig) nameSegments(name string, matches []fuzzy.Range) []widget.RichTextSegment {
+	plain := widget.RichTextStyle{Inline: true, TextStyle: fyne.TextStyle{Bold: true}}
+	matched := plain
+	matched.ColorName = t.MatchColor
+
+	runes := []rune(name)
+	segments := make([]widget.RichTextSegment, 0, 2*len(matches)+1)
+	add := func(from, to int, style widget.RichTextStyle) {
+		if from < to {
+			segments = append(segments, &widget.TextSegment{Text: string(runes[from:to]), Style: style})
+		}
+	}
+
+	pos := 0
+	for _, m := range matches {
+		if m.Start < pos || m.End > len(runes) {
+			break
+		}
+		add(pos, m.Start, plain)
+		add(m.Start, m.End, matched)
+		pos = m.End
+	}
+	add(pos, len(runes), plain)
+	return segments
+}
+
 // ComputeListItemHeight devuelve la altura recomendada para un item según el tema.
 func (t *ThemeConfig) ComputeListItemHeight() float32 {
 	return t.ListItemHeight
@@ -199,6 +232,9 @@ func (t *ThemeConfig) Merge(overrides *ThemeConfig) *ThemeConfig {
 	if overrides.HighlightColor != nil {
 		out.HighlightColor = overrides.HighlightColor
 	}
+	if overrides.MatchColor != "" {
+		out.MatchColor = overrides.MatchColor
+	}
 	out.FixedWindow = overrides.FixedWindow
 	return &out
 }
@@ -0,0 +1,200 @@
+// Package units convierte cantidades entre unidades sin conexión: longitud,
+// masa, temperatura, volumen, tamaño de datos, tiempo y velocidad.
+//
+// La consulta es "<cantidad> <unidad> <conector> <unidad>", por ejemplo
+// "10 km in mi", "72f to c" o "5 GiB = MB". Se aceptan símbolos, nombres en
+// singular o plural y conectores en inglés, español y catalán.
+package units
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/adelylria/GoFinder/core/calc"
+	"github.com/adelylria/GoFinder/core/i18n"
+)
+
+type Dimension int
+
+const (
+	Length Dimension = iota
+	Mass
+	Temperature
+	Volume
+	Data
+	Time
+	Speed
+)
+
+// Unit convierte a y desde la unidad base de su dimensión. Las lineales
+// solo tienen factor; la temperatura necesita además un desplazamiento.
+type Unit struct {
+	Symbol string
+	Dim    Dimension
+	factor float64 // valor de una unidad en la unidad base
+	offset float64 // se suma antes de aplicar el factor (temperaturas)
+}
+
+func (u Unit) toBase(v float64) float64   { return (v + u.offset) * u.factor }
+func (u Unit) fromBase(v float64) float64 { return v/u.factor - u.offset }
+
+// Conversion es el resultado de Convert.
+type Conversion struct {
+	Value    float64
+	From, To Unit
+}
+
+// String devuelve el valor con los separadores del idioma activo.
+func (c Conversion) String() string {
+	return i18n.LocalizeNumber(calc.FormatNumber(c.Value))
+}
+
+var queryPattern = regexp.MustCompile(
+	`^\s*([-+−]?[\d.,\s]*\d)\s*(.+?)\s*(?:\s(?:in|to|as|into|en|a)\s|=|->|→)\s*(.+?)\s*$`)
+
+// Convert interpreta query. Devuelve error si no es una conversión o las
+// unidades no son de la misma dimensión.
+func Convert(query string) (Conversion, error) {
+	m := queryPattern.FindStringSubmatch(query)
+	if m == nil {
+		return Conversion{}, errors.New("no es una conversión")
+	}
+	value, err := i18n.ParseNumber(m[1])
+	if err != nil {
+		return Conversion{}, err
+	}
+	from, ok := Lookup(m[2])
+	if !ok {
+		return Conversion{}, fmt.Errorf("unidad desconocida %q", m[2])
+	}
+	to, ok := Lookup(m[3])
+	if !ok {
+		return Conversion{}, fmt.Errorf("unidad desconocida %q", m[3])
+	}
+	if from.Dim != to.Dim {
+		return Conversion{}, fmt.Errorf("no se puede convertir %s en %s", from.Symbol, to.Symbol)
+	}
+	return Conversion{Value: to.fromBase(from.toBase(value)), From: from, To: to}, nil
+}
+
+// Lookup busca una unidad por símbolo o nombre. Primero distingue
+// mayúsculas, para separar "Mb" (megabit) de "MB" (megabyte); si no,
+// prueba sin distinguirlas y sin la "s" del plural.
+func Lookup(name string) (Unit, bool) {
+	name = strings.TrimSpace(strings.NewReplacer("°", "", "º", "").Replace(name))
+	if u, ok := exactUnits[name]; ok {
+		return u, true
+	}
+	lower := strings.ToLower(name)
+	if u, ok := foldedUnits[lower]; ok {
+		return u, true
+	}
+	if strings.HasSuffix(lower, "s") {
+		u, ok := foldedUnits[strings.TrimSuffix(lower, "s")]
+		return u, ok
+	}
+	return Unit{}, false
+}
+
+type unitDef struct {
+	unit    Unit
+	aliases []string
+}
+
+func linear(symbol string, dim Dimension, factor float64, aliases ...string) unitDef {
+	return unitDef{Unit{Symbol: symbol, Dim: dim, factor: factor}, aliases}
+}
+
+// Unidades base: metro, kilogramo, kelvin, litro, byte, segundo y m/s.
+var definitions = []unitDef{
+	linear("nm", Length, 1e-9, "nanometer", "nanometre"),
+	linear("µm", Length, 1e-6, "um", "micron", "micrometer", "micrometre"),
+	linear("mm", Length, 1e-3, "millimeter", "millimetre", "milímetro"),
+	linear("cm", Length, 1e-2, "centimeter", "centimetre", "centímetro"),
+	linear("m", Length, 1, "meter", "metre", "metro"),
+	linear("km", Length, 1e3, "kilometer", "kilometre", "kilómetro", "quilòmetre"),
+	linear("in", Length, 0.0254, "inch", "inches", "pulgada"),
+	linear("ft", Length, 0.3048, "foot", "feet", "pie"),
+	linear("yd", Length, 0.9144, "yard", "yarda"),
+	linear("mi", Length, 1609.344, "mile", "milla"),
+	linear("nmi", Length, 1852, "nautical mile", "milla náutica"),
+
+	linear("mg", Mass, 1e-6, "milligram", "miligramo"),
+	linear("g", Mass, 1e-3, "gram", "gramo", "gr"),
+	linear("kg", Mass, 1, "kilogram", "kilo", "kilogramo", "quilogram"),
+	linear("t", Mass, 1e3, "tonne", "tonelada", "ton"),
+	linear("oz", Mass, 0.028349523125, "ounce", "onza"),
+	linear("lb", Mass, 0.45359237, "lbs", "pound", "libra"),
+	linear("st", Mass, 6.35029318, "stone"),
+
+	{Unit{Symbol: "°C", Dim: Temperature, factor: 1, offset: 273.15}, []string{"c", "celsius", "centigrade"}},
+	{Unit{Symbol: "°F", Dim: Temperature, factor: 5.0 / 9, offset: 459.67}, []string{"f", "fahrenheit"}},
+	{Unit{Symbol: "K", Dim: Temperature, factor: 1}, []string{"k", "kelvin"}},
+
+	linear("ml", Volume, 1e-3, "mL", "milliliter", "millilitre", "mililitro"),
+	linear("cl", Volume, 1e-2, "cL", "centiliter", "centilitre", "centilitro"),
+	linear("dl", Volume, 1e-1, "dL", "deciliter", "decilitre", "decilitro"),
+	linear("l", Volume, 1, "L", "liter", "litre", "litro"),
+	linear("m³", Volume, 1e3, "m3", "cubic meter", "cubic metre"),
+	linear("cm³", Volume, 1e-3, "cm3", "cc"),
+	linear("tsp", Volume, 0.00492892159375, "teaspoon"),
+	linear("tbsp", Volume, 0.01478676478125, "tablespoon"),
+	linear("fl oz", Volume, 0.0295735295625, "floz", "fluid ounce"),
+	linear("cup", Volume, 0.2365882365, "taza"),
+	linear("pt", Volume, 0.473176473, "pint"),
+	linear("qt", Volume, 0.946352946, "quart"),
+	linear("gal", Volume, 3.785411784, "gallon", "galón"),
+
+	linear("B", Data, 1, "byte", "octet"),
+	linear("bit", Data, 0.125, "b"),
+	linear("kB", Data, 1e3, "KB", "kilobyte"),
+	linear("MB", Data, 1e6, "megabyte"),
+	linear("GB", Data, 1e9, "gigabyte"),
+	linear("TB", Data, 1e12, "terabyte"),
+	linear("PB", Data, 1e15, "petabyte"),
+	linear("KiB", Data, 1<<10, "kibibyte"),
+	linear("MiB", Data, 1<<20, "mebibyte"),
+	linear("GiB", Data, 1<<30, "gibibyte"),
+	linear("TiB", Data, 1<<40, "tebibyte"),
+	linear("kbit", Data, 1e3/8, "kilobit"),
+	linear("Mbit", Data, 1e6/8, "Mb", "megabit"),
+	linear("Gbit", Data, 1e9/8, "Gb", "gigabit"),
+
+	linear("ms", Time, 1e-3, "millisecond", "milisegundo"),
+	linear("s", Time, 1, "sec", "second", "segundo", "segon"),
+	linear("min", Time, 60, "minute", "minuto", "minut"),
+	linear("h", Time, 3600, "hr", "hour", "hora"),
+	linear("d", Time, 86400, "day", "día", "dia"),
+	linear("wk", Time, 7*86400, "week", "semana", "setmana"),
+	linear("yr", Time, 365.25*86400, "year", "año", "any"),
+
+	linear("m/s", Speed, 1, "mps"),
+	linear("km/h", Speed, 1/3.6, "kmh", "kph"),
+	linear("mph", Speed, 0.44704, "mi/h"),
+	linear("kn", Speed, 0.514444, "kt", "knot", "nudo"),
+	linear("ft/s", Speed, 0.3048, "fps"),
+}
+
+var exactUnits, foldedUnits = indexUnits()
+
+// indexUnits construye los dos índices. En el que no distingue mayúsculas
+// gana la primera unidad registrada con cada nombre; por eso los bytes van
+// antes que los bits y "kb" y "mb" son kB y MB. Los bits se escriben con
+// mayúscula ("Mb") o como "kbit".
+func indexUnits() (map[string]Unit, map[string]Unit) {
+	exact := make(map[string]Unit)
+	folded := make(map[string]Unit)
+	for _, def := range definitions {
+		names := append([]string{def.unit.Symbol}, def.aliases...)
+		for _, name := range names {
+			exact[name] = def.unit
+			key := strings.ToLower(name)
+			if _, taken := folded[key]; !taken {
+				folded[key] = def.unit
+			}
+		}
+	}
+	return exact, folded
+}
@@ -0,0 +1,57 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/adelylria/GoFinder/core/i18n"
+)
+
+func TestConvert(t *testing.T) {
+	defer i18n.SetLanguage(i18n.CurrentLanguage())
+	i18n.SetLanguage(i18n.English)
+
+	tests := []struct {
+		query string
+		want  string
+		to    string
+	}{
+		{"10 km in mi", "6.21371192237", "mi"},
+		{"72f to c", "22.2222222222", "°C"},
+		{"-40 °C in °F", "-40", "°F"},
+		{"0 K to celsius", "-273.15", "°C"},
+		{"5 GiB in MB", "5,368.70912", "MB"},
+		{"5 gib in mb", "5,368.70912", "MB"},
+		{"100 Mb in MB", "12.5", "MB"},
+		{"1 mb in kb", "1,000", "kB"},
+		{"1 kb in mb", "0.001", "MB"},
+		{"8 bits = bytes", "1", "B"},
+		{"1,500 m -> km", "1.5", "km"},
+		{"2 hours in minutes", "120", "min"},
+		{"100 km/h to mph", "62.1371192237", "mph"},
+		{"1 gal in l", "3.785411784", "l"},
+		{"12 in in cm", "30.48", "cm"},
+		{"1 lb en kg", "0.45359237", "kg"},
+	}
+	for _, tt := range tests {
+		got, err := Convert(tt.query)
+		if err != nil {
+			t.Fatalf("Convert(%q): %v", tt.query, err)
+		}
+		if got.String() != tt.want || got.To.Symbol != tt.to {
+			t.Fatalf("Convert(%q) = %s %s, se esperaba %s %s", tt.query, got, got.To.Symbol, tt.want, tt.to)
+		}
+	}
+
+	i18n.SetLanguage(i18n.Spanish)
+	if got, err := Convert("1,5 km a m"); err != nil || got.String() != "1500" {
+		t.Fatalf("es: Convert = %v, %v", got, err)
+	}
+}
+
+func TestConvertRejects(t *testing.T) {
+	for _, query := range []string{"", "firefox", "10 km", "10 km in kg", "km in mi", "10 parsecs in m", "2+2"} {
+		if got, err := Convert(query); err == nil {
+			t.Fatalf("Convert(%q) = %+v, se esperaba error", query, got)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/adelylria/GoFinder/models"
+)
+
+// ---- App Index ----
+// Guarda las apps descubiertas para mostrarlas nada más arrancar, mientras
+// el escaneo completo corre en segundo plano. Cada app lleva la fecha de
+// modificación de su fichero de origen; las que cambiaron o desaparecieron
+// no se muestran hasta que el escaneo las vuelva a encontrar.
+
+const (
+	// appIndexVersion se incrementa al cambiar models.Application de forma
+	// que los índices antiguos necesiten migración (ver migrateAppIndex).
+	appIndexVersion = 1
+	appIndexFile    = "apps.json"
+)
+
+type appIndexEntry struct {
+	App     models.Application `json:"app"`
+	ModTime int64              `json:"mtime"`
+}
+
+type appIndexData struct {
+	Version int             `json:"version"`
+	Apps    []appIndexEntry `json:"apps"`
+}
+
+var appIndexMu sync.Mutex
+
+// AppIndexPath devuelve la ruta del índice de aplicaciones.
+func AppIndexPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "GoFinder", appIndexFile), nil
+}
+
+// LoadAppIndex devuelve las apps del índice cuyo origen no ha cambiado. Si
+// no hay índice, o es de una versión desconocida, devuelve nil.
+func LoadAppIndex() ([]models.Application, error) {
+	path, err := AppIndexPath()
+	if err != nil {
+		return nil, err
+	}
+	return loadAppIndexFile(path)
+}
+
+// SaveAppIndex reemplaza el índice con apps.
+func SaveAppIndex(apps []models.Application) error {
+	path, err := AppIndexPath()
+	if err != nil {
+		return err
+	}
+	return saveAppIndexFile(path, apps)
+}
+
+func loadAppIndexFile(path string) ([]models.Application, error) {
+	appIndexMu.Lock()
+	data, err := os.ReadFile(path)
+	appIndexMu.Unlock()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var index appIndexData
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, err
+	}
+	if !migrateAppIndex(&index) {
+		return nil, nil
+	}
+
+	apps := make([]models.Application, 0, len(index.Apps))
+	for _, entry := range index.Apps {
+		if mtime := sourceModTime(entry.App.SourcePath); mtime >= 0 && mtime == entry.ModTime {
+			apps = append(apps, entry.App)
+		}
+	}
+	return apps, nil
+}
+
+// migrateAppIndex lleva un índice antiguo a appIndexVersion. Devuelve false
+// si no sabe hacerlo (por ejemplo, un índice de una versión más nueva), y
+// entonces se descarta y se reconstruye con el escaneo.
+func migrateAppIndex(index *appIndexData) bool {
+	switch index.Version {
+	case appIndexVersion:
+		return true
+	default:
+		return false
+	}
+}
+
+func saveAppIndexFile(path string, apps []models.Application) error {
+	index := appIndexData{
+		Version: appIndexVersion,
+		Apps:    make([]appIndexEntry, 0, len(apps)),
+	}
+	for _, app := range apps {
+		index.Apps = append(index.Apps, appIndexEntry{App: app, ModTime: sourceModTime(app.SourcePath)})
+	}
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+
+	appIndexMu.Lock()
+	defer appIndexMu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// Escribir y renombrar para no dejar un índice a medias si se cierra
+	// la app mientras tanto.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// sourceModTime devuelve la fecha de modificación del origen, -1 si no
+// existe y 0 si la app no tiene fichero de origen.
+func sourceModTime(path string) int64 {
+	if path == "" {
+		return 0
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return -1
+	}
+	return info.ModTime().UnixNano()
+}
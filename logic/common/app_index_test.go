@@ -0,0 +1,57 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adelylria/GoFinder/models"
+)
+
+func TestAppIndex(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "apps.json")
+
+	var apps []models.Application
+	for _, name := range []string{"editor", "mail", "gone"} {
+		source := filepath.Join(dir, name+".desktop")
+		if err := os.WriteFile(source, []byte("[Desktop Entry]"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		app := models.Application{Name: name, SourcePath: source, Keywords: []string{"k"}}
+		app.DesktopID = name + ".desktop"
+		app.AssignStableID()
+		apps = append(apps, app)
+	}
+	if err := saveAppIndexFile(path, apps); err != nil {
+		t.Fatal(err)
+	}
+
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(apps[1].SourcePath, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(apps[2].SourcePath); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := loadAppIndexFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 1 || loaded[0].ID != "desktop:editor.desktop" || loaded[0].Keywords[0] != "k" {
+		t.Fatalf("loaded = %+v, want only the unchanged editor", loaded)
+	}
+}
+
+func TestAppIndexUnknownVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apps.json")
+	if err := os.WriteFile(path, []byte(`{"version":99,"apps":[{"app":{"Name":"x"},"mtime":0}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadAppIndexFile(path)
+	if err != nil || loaded != nil {
+		t.Fatalf("loaded = %v, %v; want nil index", loaded, err)
+	}
+}
@@ -18,10 +18,12 @@ func GetAppDirs() []string {
 			filepath.Join(os.Getenv("USERPROFILE"), "Desktop"),
 		}
 	}
-	return []string{
-		"/usr/share/applications",
-		filepath.Join(os.Getenv("HOME"), ".local/share/applications"),
+	dataDirs := XDGDataDirs()
+	dirs := make([]string, 0, len(dataDirs))
+	for _, dir := range dataDirs {
+		dirs = append(dirs, filepath.Join(dir, "applications"))
 	}
+	return dirs
 }
 
 // isValidApp verifica si una aplicación tiene los campos mínimos requeridos
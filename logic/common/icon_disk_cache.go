@@ -0,0 +1,337 @@
+package common
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// ---- Disk Icon Cache ----
+// Guarda en disco los iconos ya convertidos para no repetir la extracción
+// (ExtractIconEx/SHGetFileInfo en Windows, decodificación en Linux) en cada
+// arranque. Cada entrada se identifica por ruta de origen, índice y tamaño,
+// y se invalida cuando cambia la fecha de modificación del origen.
+
+const (
+	diskCacheVersion  = 1
+	diskCacheMaxBytes = 32 << 20
+	diskCacheSaveWait = 2 * time.Second
+	diskCacheIndex    = "index.json"
+)
+
+type diskCacheEntry struct {
+	Source   string `json:"source"`
+	Index    int    `json:"index"`
+	Size     int    `json:"size"`
+	ModTime  int64  `json:"mtime"`
+	File     string `json:"file"`
+	Name     string `json:"name"`
+	Bytes    int64  `json:"bytes"`
+	LastUsed int64  `json:"last_used"`
+}
+
+type diskCacheFile struct {
+	Version int               `json:"version"`
+	Entries []*diskCacheEntry `json:"entries"`
+}
+
+type diskIconCache struct {
+	mu        sync.Mutex
+	dir       string
+	maxBytes  int64
+	entries   map[string]*diskCacheEntry
+	total     int64
+	loaded    bool
+	saveTimer *time.Timer
+}
+
+var (
+	diskCacheOnce sync.Once
+	diskCache     *diskIconCache
+)
+
+// IconCacheKey es la clave común de las cachés de iconos (memoria y disco).
+func IconCacheKey(source string, index int) string {
+	return fmt.Sprintf("%s|%d", source, index)
+}
+
+// IconCacheDir devuelve el directorio de la caché de iconos en disco.
+func IconCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "GoFinder", "icons"), nil
+}
+
+func defaultDiskCache() *diskIconCache {
+	diskCacheOnce.Do(func() {
+		if dir, err := IconCacheDir(); err == nil {
+			diskCache = newDiskIconCache(dir, diskCacheMaxBytes)
+		}
+	})
+	return diskCache
+}
+
+func newDiskIconCache(dir string, maxBytes int64) *diskIconCache {
+	return &diskIconCache{dir: dir, maxBytes: maxBytes, entries: make(map[string]*diskCacheEntry)}
+}
+
+// WarmIconCache carga en la caché de memoria los iconos guardados en disco
+// para el tamaño dado, descartando los que tengan el origen modificado o
+// eliminado. Solo se leen los usados más recientemente que caben en la
+// caché de memoria; el resto se carga bajo demanda con DiskCacheGet.
+func WarmIconCache(size int) {
+	cache := defaultDiskCache()
+	if cache == nil {
+		return
+	}
+	for key, res := range cache.warm(size, iconCacheMaxEntries) {
+		CacheSet(key, res)
+	}
+}
+
+// DiskCacheGet devuelve un icono de la caché de disco si sigue vigente.
+func DiskCacheGet(source string, index, size int) (fyne.Resource, bool) {
+	cache := defaultDiskCache()
+	if cache == nil {
+		return nil, false
+	}
+	return cache.get(source, index, size)
+}
+
+// DiskCacheSet guarda un icono recién extraído en la caché de disco.
+func DiskCacheSet(source string, index, size int, res fyne.Resource) {
+	if cache := defaultDiskCache(); cache != nil {
+		cache.set(source, index, size, res)
+	}
+}
+
+func diskEntryKey(source string, index, size int) string {
+	return fmt.Sprintf("%s|%d|%d", source, index, size)
+}
+
+// warm lee como mucho limit iconos del tamaño dado, los usados más
+// recientemente primero.
+func (c *diskIconCache) warm(size, limit int) map[string]fyne.Resource {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadLocked()
+
+	var keys []string
+	for key, entry := range c.entries {
+		if entry.Size != size {
+			continue
+		}
+		if !entry.fresh() {
+			c.removeLocked(key)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return c.entries[keys[i]].LastUsed > c.entries[keys[j]].LastUsed
+	})
+
+	resources := make(map[string]fyne.Resource)
+	now := time.Now().Unix()
+	for _, key := range keys {
+		if len(resources) == limit {
+			break
+		}
+		if res, ok := c.readLocked(key, now); ok {
+			entry := c.entries[key]
+			resources[IconCacheKey(entry.Source, entry.Index)] = res
+		}
+	}
+	if len(c.entries) > 0 {
+		c.scheduleSaveLocked()
+	}
+	return resources
+}
+
+func (c *diskIconCache) get(source string, index, size int) (fyne.Resource, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadLocked()
+
+	key := diskEntryKey(source, index, size)
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !entry.fresh() {
+		c.removeLocked(key)
+		c.scheduleSaveLocked()
+		return nil, false
+	}
+	res, ok := c.readLocked(key, time.Now().Unix())
+	c.scheduleSaveLocked()
+	return res, ok
+}
+
+// readLocked lee el icono de la entrada y la marca como usada; si el fichero
+// ya no está, la elimina.
+func (c *diskIconCache) readLocked(key string, now int64) (fyne.Resource, bool) {
+	entry := c.entries[key]
+	data, err := os.ReadFile(filepath.Join(c.dir, entry.File))
+	if err != nil {
+		c.removeLocked(key)
+		return nil, false
+	}
+	entry.LastUsed = now
+	return fyne.NewStaticResource(entry.Name, data), true
+}
+
+func (c *diskIconCache) set(source string, index, size int, res fyne.Resource) {
+	if source == "" || res == nil {
+		return
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadLocked()
+
+	key := diskEntryKey(source, index, size)
+	sum := sha1.Sum([]byte(key))
+	entry := &diskCacheEntry{
+		Source:   source,
+		Index:    index,
+		Size:     size,
+		ModTime:  info.ModTime().UnixNano(),
+		File:     hex.EncodeToString(sum[:]) + filepath.Ext(res.Name()),
+		Name:     res.Name(),
+		Bytes:    int64(len(res.Content())),
+		LastUsed: time.Now().Unix(),
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(c.dir, entry.File), res.Content(), 0o644); err != nil {
+		return
+	}
+
+	if old, ok := c.entries[key]; ok {
+		c.total -= old.Bytes
+	}
+	c.entries[key] = entry
+	c.total += entry.Bytes
+	c.evictLocked()
+	c.scheduleSaveLocked()
+}
+
+// evictLocked elimina las entradas usadas hace más tiempo hasta quedar por
+// debajo del límite de tamaño.
+func (c *diskIconCache) evictLocked() {
+	if c.total <= c.maxBytes {
+		return
+	}
+	keys := make([]string, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return c.entries[keys[i]].LastUsed < c.entries[keys[j]].LastUsed
+	})
+	for _, key := range keys {
+		if c.total <= c.maxBytes {
+			return
+		}
+		c.removeLocked(key)
+	}
+}
+
+func (c *diskIconCache) removeLocked(key string) {
+	entry, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	_ = os.Remove(filepath.Join(c.dir, entry.File))
+	c.total -= entry.Bytes
+	delete(c.entries, key)
+}
+
+func (e *diskCacheEntry) fresh() bool {
+	info, err := os.Stat(e.Source)
+	return err == nil && info.ModTime().UnixNano() == e.ModTime
+}
+
+// loadLocked lee el índice una sola vez y borra los ficheros huérfanos
+// (por ejemplo, si el proceso terminó antes de guardar el índice).
+func (c *diskIconCache) loadLocked() {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+
+	data, err := os.ReadFile(filepath.Join(c.dir, diskCacheIndex))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	var file diskCacheFile
+	if err == nil && json.Unmarshal(data, &file) == nil && file.Version == diskCacheVersion {
+		for _, entry := range file.Entries {
+			c.entries[diskEntryKey(entry.Source, entry.Index, entry.Size)] = entry
+			c.total += entry.Bytes
+		}
+	}
+
+	known := make(map[string]bool, len(c.entries))
+	for _, entry := range c.entries {
+		known[entry.File] = true
+	}
+	files, _ := os.ReadDir(c.dir)
+	for _, f := range files {
+		if f.IsDir() || f.Name() == diskCacheIndex || known[f.Name()] {
+			continue
+		}
+		_ = os.Remove(filepath.Join(c.dir, f.Name()))
+	}
+}
+
+func (c *diskIconCache) scheduleSaveLocked() {
+	if c.saveTimer != nil {
+		c.saveTimer.Stop()
+	}
+	c.saveTimer = time.AfterFunc(diskCacheSaveWait, func() {
+		if err := c.save(); err != nil {
+			fmt.Printf("Error guardando la caché de iconos: %v\n", err)
+		}
+	})
+}
+
+func (c *diskIconCache) save() error {
+	c.mu.Lock()
+	file := diskCacheFile{Version: diskCacheVersion, Entries: make([]*diskCacheEntry, 0, len(c.entries))}
+	for _, entry := range c.entries {
+		copied := *entry
+		file.Entries = append(file.Entries, &copied)
+	}
+	c.mu.Unlock()
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	tmp := filepath.Join(c.dir, diskCacheIndex+".tmp")
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(c.dir, diskCacheIndex))
+}
@@ -0,0 +1,105 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+func TestDiskIconCache(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "app.png")
+	if err := os.WriteFile(source, []byte("source"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := newTestDiskCache(t, filepath.Join(dir, "cache"), 1<<20)
+	cache.set(source, 0, 48, fyne.NewStaticResource("app.png", []byte("png")))
+	if err := cache.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := newTestDiskCache(t, filepath.Join(dir, "cache"), 1<<20)
+	res, ok := reloaded.warm(48, 10)[IconCacheKey(source, 0)]
+	if !ok || string(res.Content()) != "png" {
+		t.Fatalf("warm did not return cached icon: %v", res)
+	}
+	if len(reloaded.warm(32, 10)) != 0 {
+		t.Fatal("entries of another size must not be warmed")
+	}
+
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(source, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if len(reloaded.warm(48, 10)) != 0 || len(reloaded.entries) != 0 {
+		t.Fatal("stale entry was not evicted")
+	}
+}
+
+func TestDiskIconCacheGet(t *testing.T) {
+	dir := t.TempDir()
+	cache := newTestDiskCache(t, filepath.Join(dir, "cache"), 1<<20)
+	var sources []string
+	for i, name := range []string{"a", "b", "c"} {
+		source := filepath.Join(dir, name)
+		if err := os.WriteFile(source, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		cache.set(source, 0, 48, fyne.NewStaticResource(name+".png", []byte(name)))
+		cache.entries[diskEntryKey(source, 0, 48)].LastUsed = int64(i)
+		sources = append(sources, source)
+	}
+
+	warmed := cache.warm(48, 2)
+	if len(warmed) != 2 {
+		t.Fatalf("warm(48, 2) returned %d icons", len(warmed))
+	}
+	if _, ok := warmed[IconCacheKey(sources[0], 0)]; ok {
+		t.Fatal("least recently used icon should not be warmed")
+	}
+
+	res, ok := cache.get(sources[0], 0, 48)
+	if !ok || string(res.Content()) != "a" {
+		t.Fatalf("get did not return the icon left out of warm: %v", res)
+	}
+	if _, ok := cache.get(sources[0], 0, 32); ok {
+		t.Fatal("get must not return an icon of another size")
+	}
+}
+
+func TestDiskIconCacheEviction(t *testing.T) {
+	dir := t.TempDir()
+	cache := newTestDiskCache(t, filepath.Join(dir, "cache"), 10)
+	for i, name := range []string{"a", "b", "c"} {
+		source := filepath.Join(dir, name)
+		if err := os.WriteFile(source, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		cache.set(source, 0, 48, fyne.NewStaticResource(name+".png", []byte("12345")))
+		cache.entries[diskEntryKey(source, 0, 48)].LastUsed = int64(i)
+	}
+	if cache.total > 10 || len(cache.entries) != 2 {
+		t.Fatalf("total = %d, entries = %d", cache.total, len(cache.entries))
+	}
+	if _, ok := cache.entries[diskEntryKey(filepath.Join(dir, "a"), 0, 48)]; ok {
+		t.Fatal("least recently used entry should be evicted")
+	}
+}
+
+// newTestDiskCache cancela el guardado diferido al terminar el test para que
+// no escriba en un directorio temporal ya borrado.
+func newTestDiskCache(t *testing.T, dir string, maxBytes int64) *diskIconCache {
+	cache := newDiskIconCache(dir, maxBytes)
+	t.Cleanup(func() {
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+		if cache.saveTimer != nil {
+			cache.saveTimer.Stop()
+		}
+	})
+	return cache
+}
@@ -0,0 +1,123 @@
+package common
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+
+	"fyne.io/fyne/v2"
+)
+
+// IconLRU es una caché de iconos en memoria acotada tanto por número de
+// entradas como por bytes; al superar cualquiera de los dos límites se
+// descartan primero los iconos usados hace más tiempo.
+type IconLRU struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	bytes      int64
+	order      *list.List // el frente es el más reciente
+	items      map[string]*list.Element
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+type iconLRUItem struct {
+	key  string
+	res  fyne.Resource
+	size int64
+}
+
+// IconCacheStats resume el estado de la caché para logs o vistas de depuración.
+type IconCacheStats struct {
+	Entries   int
+	Bytes     int64
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+func (s IconCacheStats) String() string {
+	return fmt.Sprintf("icon cache: %d entries, %d bytes, %d hits, %d misses, %d evictions",
+		s.Entries, s.Bytes, s.Hits, s.Misses, s.Evictions)
+}
+
+func NewIconLRU(maxEntries int, maxBytes int64) *IconLRU {
+	return &IconLRU{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		order:      list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+func (c *IconLRU) Get(key string) (fyne.Resource, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.order.MoveToFront(elem)
+	return elem.Value.(*iconLRUItem).res, true
+}
+
+// Peek es como Get pero no cuenta aciertos ni fallos: sirve para las
+// comprobaciones de la UI y la precarga, que no son peticiones de icono.
+func (c *IconLRU) Peek(key string) (fyne.Resource, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*iconLRUItem).res, true
+}
+
+func (c *IconLRU) Set(key string, res fyne.Resource) {
+	var size int64
+	if res != nil {
+		size = int64(len(res.Content()))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[key]; ok {
+		item := elem.Value.(*iconLRUItem)
+		c.bytes += size - item.size
+		item.res, item.size = res, size
+		c.order.MoveToFront(elem)
+	} else {
+		c.items[key] = c.order.PushFront(&iconLRUItem{key: key, res: res, size: size})
+		c.bytes += size
+	}
+	c.evictLocked()
+}
+
+func (c *IconLRU) Stats() IconCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return IconCacheStats{
+		Entries:   c.order.Len(),
+		Bytes:     c.bytes,
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+	}
+}
+
+func (c *IconLRU) evictLocked() {
+	for c.order.Len() > 0 && (c.order.Len() > c.maxEntries || c.bytes > c.maxBytes) {
+		elem := c.order.Back()
+		item := elem.Value.(*iconLRUItem)
+		c.order.Remove(elem)
+		delete(c.items, item.key)
+		c.bytes -= item.size
+		c.evictions++
+	}
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+)
+
+func TestIconLRU(t *testing.T) {
+	cache := NewIconLRU(2, 8)
+	cache.Set("a", fyne.NewStaticResource("a.png", []byte("1234")))
+	cache.Set("b", fyne.NewStaticResource("b.png", []byte("1234")))
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("a should be cached")
+	}
+	// Peek no cuenta, pero sí marca la entrada como usada.
+	if _, ok := cache.Peek("b"); !ok {
+		t.Fatal("b should be cached")
+	}
+	if _, ok := cache.Peek("x"); ok {
+		t.Fatal("x should not be cached")
+	}
+	cache.Peek("a")
+
+	// "b" es ahora el menos reciente y sale por límite de entradas.
+	cache.Set("c", fyne.NewStaticResource("c.png", []byte("12")))
+	if _, ok := cache.Get("b"); ok {
+		t.Fatal("b should be evicted")
+	}
+
+	// Un icono grande fuerza desalojos por bytes.
+	cache.Set("d", fyne.NewStaticResource("d.png", []byte("1234567")))
+
+	stats := cache.Stats()
+	if stats.Entries != 1 || stats.Bytes != 7 {
+		t.Fatalf("unexpected size: %+v", stats)
+	}
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Evictions != 3 {
+		t.Fatalf("unexpected counters: %+v", stats)
+	}
+}
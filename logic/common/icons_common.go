@@ -5,33 +5,45 @@ import (
 	"image"
 	"image/png"
 	"os"
-	"sync"
+	"path/filepath"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"github.com/fyne-io/image/ico"
 )
 
 // ---- Icon Cache ----
-var (
-	IconCache = make(map[string]fyne.Resource)
-	CacheMu   sync.RWMutex
+const (
+	iconCacheMaxEntries = 512
+	iconCacheMaxBytes   = 16 << 20
 )
 
+var IconCache = NewIconLRU(iconCacheMaxEntries, iconCacheMaxBytes)
+
 func CacheGet(key string) (fyne.Resource, bool) {
-	CacheMu.RLock()
-	defer CacheMu.RUnlock()
-	r, ok := IconCache[key]
-	return r, ok
+	return IconCache.Get(key)
+}
+
+// CachePeek consulta la caché sin contar acierto ni fallo.
+func CachePeek(key string) (fyne.Resource, bool) {
+	return IconCache.Peek(key)
 }
 
 func CacheSet(key string, res fyne.Resource) {
-	CacheMu.Lock()
-	defer CacheMu.Unlock()
-	IconCache[key] = res
+	IconCache.Set(key, res)
+}
+
+// CacheStats devuelve los contadores de aciertos, fallos y desalojos.
+func CacheStats() IconCacheStats {
+	return IconCache.Stats()
 }
 
 // ---- Helpers to load image files ----
 func LoadImageFileToResource(path, nameHint string) fyne.Resource {
+	if strings.EqualFold(filepath.Ext(path), ".svg") {
+		return LoadSVGToResource(path, nameHint)
+	}
+
 	file, err := os.Open(path)
 	if err != nil {
 		return nil
@@ -51,6 +63,16 @@ func LoadImageFileToResource(path, nameHint string) fyne.Resource {
 	return fyne.NewStaticResource(SanitizeResourceName(nameHint)+".png", buf.Bytes())
 }
 
+// LoadSVGToResource devuelve el SVG tal cual: Fyne lo rasteriza al tamaño
+// con el que se dibuja el icono, así que no pierde nitidez al escalar.
+func LoadSVGToResource(path, nameHint string) fyne.Resource {
+	data, err := os.ReadFile(path)
+	if err != nil || !bytes.Contains(data, []byte("<svg")) {
+		return nil
+	}
+	return fyne.NewStaticResource(SanitizeResourceName(nameHint)+".svg", data)
+}
+
 func LoadICOToResource(path, nameHint string) fyne.Resource {
 	file, err := os.Open(path)
 	if err != nil {
@@ -0,0 +1,63 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// extraDataDirs are data dirs that sandboxed package managers export to, in
+// case the session did not add them to XDG_DATA_DIRS.
+var extraDataDirs = []string{
+	"/var/lib/flatpak/exports/share",
+	"/var/lib/snapd/desktop",
+}
+
+// XDGDataHome devuelve $XDG_DATA_HOME o ~/.local/share por defecto.
+func XDGDataHome() string {
+	if dir := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(os.Getenv("HOME"), ".local", "share")
+}
+
+// XDGDataDirs returns the base data directories in precedence order, as
+// defined by the XDG Base Directory spec: XDG_DATA_HOME (plus the user
+// Flatpak exports) first, then XDG_DATA_DIRS, then the known Flatpak/Snap
+// export dirs. Relative and duplicate entries are dropped.
+func XDGDataDirs() []string {
+	dataHome := XDGDataHome()
+	dirs := []string{dataHome, filepath.Join(dataHome, "flatpak", "exports", "share")}
+
+	systemDirs := os.Getenv("XDG_DATA_DIRS")
+	if systemDirs == "" {
+		systemDirs = "/usr/local/share:/usr/share"
+	}
+	dirs = append(dirs, strings.Split(systemDirs, ":")...)
+	dirs = append(dirs, extraDataDirs...)
+
+	seen := make(map[string]bool, len(dirs))
+	result := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if !filepath.IsAbs(dir) {
+			continue
+		}
+		dir = filepath.Clean(dir)
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		result = append(result, dir)
+	}
+	return result
+}
+
+// DesktopFileID computes the desktop file ID of path relative to the
+// applications dir it was found in: "kde/foo.desktop" becomes "kde-foo.desktop".
+func DesktopFileID(appsDir, path string) string {
+	rel, err := filepath.Rel(appsDir, path)
+	if err != nil {
+		return filepath.Base(path)
+	}
+	return strings.ReplaceAll(filepath.ToSlash(rel), "/", "-")
+}
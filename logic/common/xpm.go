@@ -0,0 +1,214 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Decodificador de XPM (X PixMap, versión 3), el formato de los iconos
+// antiguos que muchos paquetes siguen instalando en /usr/share/pixmaps.
+// Se registra en image para que LoadImageFileToResource lo use sin más.
+
+var errInvalidXPM = errors.New("xpm: formato no válido")
+
+func init() {
+	image.RegisterFormat("xpm", "/* XPM */", DecodeXPM, DecodeXPMConfig)
+}
+
+// xpmColorKeys is the preference order of the color visuals of a color
+// line: color, grayscale, 4-level grayscale and monochrome.
+var xpmColorKeys = []string{"c", "g", "g4", "m"}
+
+// xpmNamedColors cubre los nombres X11 más habituales en iconos XPM.
+var xpmNamedColors = map[string]color.NRGBA{
+	"black":   {0, 0, 0, 255},
+	"white":   {255, 255, 255, 255},
+	"red":     {255, 0, 0, 255},
+	"green":   {0, 255, 0, 255},
+	"blue":    {0, 0, 255, 255},
+	"yellow":  {255, 255, 0, 255},
+	"cyan":    {0, 255, 255, 255},
+	"magenta": {255, 0, 255, 255},
+	"gray":    {190, 190, 190, 255},
+	"grey":    {190, 190, 190, 255},
+	"orange":  {255, 165, 0, 255},
+	"brown":   {165, 42, 42, 255},
+	"navy":    {0, 0, 128, 255},
+}
+
+type xpmHeader struct {
+	width, height, colors, cpp int
+}
+
+// xpmLimits acota ancho, alto, colores y caracteres por píxel: un icono
+// nunca se acerca a estos valores, y sin ellos un fichero malformado podría
+// reservar gigas o desbordar width*cpp.
+var xpmLimits = [4]int{4096, 4096, 1 << 16, 4}
+
+func DecodeXPMConfig(r io.Reader) (image.Config, error) {
+	lines, err := readXPMStrings(r)
+	if err != nil {
+		return image.Config{}, err
+	}
+	header, err := parseXPMHeader(lines[0])
+	if err != nil {
+		return image.Config{}, err
+	}
+	return image.Config{ColorModel: color.NRGBAModel, Width: header.width, Height: header.height}, nil
+}
+
+func DecodeXPM(r io.Reader) (image.Image, error) {
+	lines, err := readXPMStrings(r)
+	if err != nil {
+		return nil, err
+	}
+	header, err := parseXPMHeader(lines[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) < 1+header.colors+header.height {
+		return nil, fmt.Errorf("%w: faltan líneas", errInvalidXPM)
+	}
+
+	rows := lines[1+header.colors : 1+header.colors+header.height]
+	for y, row := range rows {
+		if len(row) < header.width*header.cpp {
+			return nil, fmt.Errorf("%w: fila %d demasiado corta", errInvalidXPM, y)
+		}
+	}
+
+	palette := make(map[string]color.NRGBA, header.colors)
+	for _, line := range lines[1 : 1+header.colors] {
+		if len(line) < header.cpp {
+			return nil, fmt.Errorf("%w: color %q", errInvalidXPM, line)
+		}
+		palette[line[:header.cpp]] = parseXPMColor(line[header.cpp:])
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, header.width, header.height))
+	for y, row := range rows {
+		for x := 0; x < header.width; x++ {
+			img.SetNRGBA(x, y, palette[row[x*header.cpp:(x+1)*header.cpp]])
+		}
+	}
+	return img, nil
+}
+
+// readXPMStrings extracts the C string literals of the file, skipping the
+// surrounding declaration and comments.
+func readXPMStrings(r io.Reader) ([]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(data, []byte("/* XPM */")) {
+		return nil, errInvalidXPM
+	}
+	data = data[len("/* XPM */"):]
+
+	var lines []string
+	for i := 0; i < len(data); i++ {
+		switch {
+		case bytes.HasPrefix(data[i:], []byte("/*")):
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return nil, errInvalidXPM
+			}
+			i += end + 3
+		case data[i] == '"':
+			end := bytes.IndexByte(data[i+1:], '"')
+			if end < 0 {
+				return nil, errInvalidXPM
+			}
+			lines = append(lines, string(data[i+1:i+1+end]))
+			i += end + 1
+		}
+	}
+	if len(lines) == 0 {
+		return nil, errInvalidXPM
+	}
+	return lines, nil
+}
+
+func parseXPMHeader(line string) (xpmHeader, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 {
+		return xpmHeader{}, fmt.Errorf("%w: cabecera %q", errInvalidXPM, line)
+	}
+	var values [4]int
+	for i := range values {
+		n, err := strconv.Atoi(fields[i])
+		if err != nil || n <= 0 || n > xpmLimits[i] {
+			return xpmHeader{}, fmt.Errorf("%w: cabecera %q", errInvalidXPM, line)
+		}
+		values[i] = n
+	}
+	return xpmHeader{width: values[0], height: values[1], colors: values[2], cpp: values[3]}, nil
+}
+
+// parseXPMColor parses the "c #RRGGBB m black ..." part of a color line.
+func parseXPMColor(spec string) color.NRGBA {
+	visuals := make(map[string]string)
+	var key string
+	var value []string
+	flush := func() {
+		if key != "" {
+			visuals[key] = strings.Join(value, " ")
+		}
+	}
+	for _, token := range strings.Fields(spec) {
+		switch token {
+		case "c", "g", "g4", "m", "s":
+			flush()
+			key, value = token, nil
+		default:
+			value = append(value, token)
+		}
+	}
+	flush()
+
+	for _, k := range xpmColorKeys {
+		if v, ok := visuals[k]; ok {
+			return xpmColorValue(v)
+		}
+	}
+	return color.NRGBA{}
+}
+
+func xpmColorValue(value string) color.NRGBA {
+	if strings.EqualFold(value, "none") {
+		return color.NRGBA{}
+	}
+	if strings.HasPrefix(value, "#") {
+		return parseXPMHex(value[1:])
+	}
+	name := strings.ToLower(strings.ReplaceAll(value, " ", ""))
+	if c, ok := xpmNamedColors[name]; ok {
+		return c
+	}
+	return color.NRGBA{A: 255}
+}
+
+// parseXPMHex admite #RGB, #RRGGBB y #RRRRGGGGBBBB.
+func parseXPMHex(hex string) color.NRGBA {
+	if len(hex) == 0 || len(hex)%3 != 0 {
+		return color.NRGBA{A: 255}
+	}
+	digits := len(hex) / 3
+	var rgb [3]uint8
+	for i := range rgb {
+		n, err := strconv.ParseUint(hex[i*digits:(i+1)*digits], 16, 32)
+		if err != nil {
+			return color.NRGBA{A: 255}
+		}
+		max := uint64(1)<<(4*digits) - 1
+		rgb[i] = uint8(n * 255 / max)
+	}
+	return color.NRGBA{R: rgb[0], G: rgb[1], B: rgb[2], A: 255}
+}
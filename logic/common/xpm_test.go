@@ -0,0 +1,64 @@
+package common
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+)
+
+const testXPM = `/* XPM */
+static char * test_xpm[] = {
+/* columns rows colors chars-per-pixel */
+"3 2 3 2 0 0",
+"   c None",
+".. c #FF0000 m black",
+"++ s shadow g4 gray m white c #00f",
+/* pixels */
+"  ..++",
+"++..  "};
+`
+
+func TestDecodeXPM(t *testing.T) {
+	img, format, err := image.Decode(bytes.NewReader([]byte(testXPM)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format != "xpm" {
+		t.Fatalf("format = %q", format)
+	}
+	if b := img.Bounds(); b.Dx() != 3 || b.Dy() != 2 {
+		t.Fatalf("bounds = %v", b)
+	}
+
+	tests := []struct {
+		x, y int
+		want color.NRGBA
+	}{
+		{0, 0, color.NRGBA{}},
+		{1, 0, color.NRGBA{R: 255, A: 255}},
+		{2, 0, color.NRGBA{B: 255, A: 255}},
+		{0, 1, color.NRGBA{B: 255, A: 255}},
+	}
+	for _, tt := range tests {
+		if got := color.NRGBAModel.Convert(img.At(tt.x, tt.y)); got != tt.want {
+			t.Fatalf("At(%d,%d) = %#v, want %#v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeXPMRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		xpm  string
+	}{
+		{"huge width", "/* XPM */\n\"1073741824 1 1 1\",\n\". c #000000\",\n\".\"\n"},
+		{"huge cpp", "/* XPM */\n\"1 1 1 1073741824\",\n\". c #000000\",\n\".\"\n"},
+		{"short row", "/* XPM */\n\"4 1 1 1\",\n\". c #000000\",\n\"..\"\n"},
+	}
+	for _, tt := range tests {
+		if _, err := DecodeXPM(bytes.NewReader([]byte(tt.xpm))); err == nil {
+			t.Fatalf("%s: DecodeXPM no devolvió error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+// Package files indexa los nombres de ficheros y carpetas bajo unas raíces
+// para buscarlos desde el lanzador.
+//
+// El índice guarda el contenido de cada carpeta junto con su fecha de
+// modificación, que cambia cuando se crea, borra o renombra algo dentro.
+// Así, al refrescar solo se vuelven a leer las carpetas que cambiaron; el
+// resto se reutiliza del índice anterior, también entre arranques.
+package files
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// indexVersion se incrementa al cambiar el formato de files.json.
+	indexVersion = 1
+	indexFile    = "files.json"
+	// MaxEntries y maxDepth acotan lo que se indexa en raíces muy grandes.
+	MaxEntries = 200000
+	maxDepth   = 16
+)
+
+// DefaultIgnore son los nombres que no se indexan ni se recorren si la
+// configuración no indica otros. Admiten comodines de filepath.Match.
+var DefaultIgnore = []string{
+	".git", ".hg", ".svn", "node_modules", "__pycache__", ".venv",
+	".cache", "Cache", "Caches", ".npm", ".cargo", ".rustup", ".gradle",
+	".m2", ".Trash*", "$RECYCLE.BIN", "AppData",
+}
+
+// Entry es un fichero o carpeta indexado.
+type Entry struct {
+	Path string
+	Rel  string // ruta con "/" relativa a su raíz; es lo que se muestra
+	Name string
+	Dir  bool
+}
+
+// dirState es el contenido de una carpeta; las subcarpetas acaban en "/".
+type dirState struct {
+	ModTime  int64    `json:"mtime"`
+	Children []string `json:"children"`
+}
+
+type indexData struct {
+	Version int                 `json:"version"`
+	Roots   []string            `json:"roots"`
+	Ignore  []string            `json:"ignore"`
+	Dirs    map[string]dirState `json:"dirs"`
+}
+
+// Index es seguro para uso concurrente. Entries devuelve un slice que no se
+// modifica después, así que se puede recorrer sin copiarlo.
+type Index struct {
+	roots  []string
+	ignore []string
+	path   string // files.json; vacío = no se guarda
+
+	mu      sync.RWMutex
+	dirs    map[string]dirState
+	entries []Entry
+
+	refreshMu   sync.Mutex
+	refreshing  atomic.Bool
+	lastRefresh atomic.Int64 // UnixNano
+}
+
+// IndexPath devuelve la ruta del índice de ficheros.
+func IndexPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "GoFinder", indexFile), nil
+}
+
+// NewIndex crea un índice vacío de roots (o del directorio personal si no
+// hay ninguna) que se guarda en path.
+func NewIndex(roots, ignore []string, path string) *Index {
+	if len(roots) == 0 {
+		if home, err := os.UserHomeDir(); err == nil {
+			roots = []string{home}
+		}
+	}
+	clean := make([]string, 0, len(roots))
+	for _, root := range roots {
+		if abs, err := filepath.Abs(root); err == nil {
+			clean = append(clean, abs)
+		}
+	}
+	return &Index{roots: clean, ignore: ignore, path: path, dirs: make(map[string]dirState)}
+}
+
+// Load recupera el índice guardado, si es de la misma versión y con las
+// mismas raíces y exclusiones. Sus carpetas se revalidan en el siguiente
+// Refresh.
+func (ix *Index) Load() error {
+	ix.refreshMu.Lock()
+	defer ix.refreshMu.Unlock()
+	if ix.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(ix.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var saved indexData
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	if saved.Version != indexVersion || !slices.Equal(saved.Roots, ix.roots) || !slices.Equal(saved.Ignore, ix.ignore) {
+		return nil
+	}
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.dirs = saved.Dirs
+	ix.entries = ix.flatten(saved.Dirs)
+	return nil
+}
+
+func (ix *Index) save(dirs map[string]dirState) error {
+	if ix.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(indexData{Version: indexVersion, Roots: ix.roots, Ignore: ix.ignore, Dirs: dirs})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(ix.path), 0o755); err != nil {
+		return err
+	}
+	tmp := ix.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, ix.path)
+}
+
+// Entries devuelve los ficheros y carpetas indexados.
+func (ix *Index) Entries() []Entry {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return ix.entries
+}
+
+// Stale indica si el último Refresh terminó hace más de maxAge.
+func (ix *Index) Stale(maxAge time.Duration) bool {
+	return !ix.refreshing.Load() && time.Since(time.Unix(0, ix.lastRefresh.Load())) > maxAge
+}
+
+// Refresh recorre las raíces leyendo solo las carpetas cuya fecha de
+// modificación cambió, y guarda el índice si hubo cambios. Si ya hay un
+// refresco en curso, espera a que termine.
+func (ix *Index) Refresh(ctx context.Context) error {
+	ix.refreshMu.Lock()
+	defer ix.refreshMu.Unlock()
+	ix.refreshing.Store(true)
+	defer ix.refreshing.Store(false)
+
+	ix.mu.RLock()
+	old := ix.dirs
+	ix.mu.RUnlock()
+
+	w := walker{ctx: ctx, ignore: ix.ignore, old: old, next: make(map[string]dirState)}
+	for _, root := range ix.roots {
+		w.walk(root, 0)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	changed := w.changed || len(w.next) != len(old)
+
+	ix.mu.Lock()
+	ix.dirs = w.next
+	ix.entries = ix.flatten(w.next)
+	ix.mu.Unlock()
+	ix.lastRefresh.Store(time.Now().UnixNano())
+
+	if !changed {
+		return nil
+	}
+	return ix.save(w.next)
+}
+
+type walker struct {
+	ctx     context.Context
+	ignore  []string
+	old     map[string]dirState
+	next    map[string]dirState
+	count   int
+	changed bool
+}
+
+func (w *walker) walk(dir string, depth int) {
+	if w.ctx.Err() != nil || w.count >= MaxEntries {
+		return
+	}
+	info, err := os.Lstat(dir)
+	if err != nil || !info.IsDir() {
+		return
+	}
+	mtime := info.ModTime().UnixNano()
+	state, ok := w.old[dir]
+	if !ok || state.ModTime != mtime {
+		state = dirState{ModTime: mtime, Children: w.readDir(dir)}
+		w.changed = true
+	}
+	w.next[dir] = state
+	w.count += len(state.Children)
+
+	if depth >= maxDepth {
+		return
+	}
+	for _, child := range state.Children {
+		if name, isDir := strings.CutSuffix(child, "/"); isDir {
+			w.walk(filepath.Join(dir, name), depth+1)
+		}
+	}
+}
+
+// readDir lista dir sin las entradas ignoradas. Los enlaces simbólicos se
+// indexan como ficheros para no seguir ciclos.
+func (w *walker) readDir(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	children := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if ignored(entry.Name(), w.ignore) {
+			continue
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			name += "/"
+		}
+		children = append(children, name)
+	}
+	return children
+}
+
+func ignored(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// flatten convierte las carpetas en la lista de entradas, en orden de
+// recorrido desde cada raíz. Con varias raíces, Rel empieza por el nombre
+// de la suya para distinguirlas.
+func (ix *Index) flatten(dirs map[string]dirState) []Entry {
+	var entries []Entry
+	var visit func(dir, rel string)
+	visit = func(dir, rel string) {
+		state, ok := dirs[dir]
+		if !ok {
+			return
+		}
+		children := append([]string(nil), state.Children...)
+		sort.Strings(children)
+		for _, child := range children {
+			name, isDir := strings.CutSuffix(child, "/")
+			path := filepath.Join(dir, name)
+			childRel := name
+			if rel != "" {
+				childRel = rel + "/" + name
+			}
+			entries = append(entries, Entry{Path: path, Rel: childRel, Name: name, Dir: isDir})
+			if isDir {
+				visit(path, childRel)
+			}
+		}
+	}
+	for _, root := range ix.roots {
+		rel := ""
+		if len(ix.roots) > 1 {
+			rel = filepath.Base(root)
+		}
+		visit(root, rel)
+	}
+	return entries
+}
@@ -0,0 +1,79 @@
+package files
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeTree(t *testing.T, root string, paths ...string) {
+	t.Helper()
+	for _, path := range paths {
+		full := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func rels(entries []Entry) []string {
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = e.Rel
+	}
+	return out
+}
+
+func TestIndexRefresh(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root,
+		"docs/report.pdf",
+		"docs/.git/HEAD",
+		"src/node_modules/x/index.js",
+		"src/main.go",
+	)
+	cache := filepath.Join(t.TempDir(), "files.json")
+	ix := NewIndex([]string{root}, DefaultIgnore, cache)
+	if err := ix.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	want := []string{"docs", "docs/report.pdf", "src", "src/main.go"}
+	if got := rels(ix.Entries()); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Entries() = %q, want %q", got, want)
+	}
+
+	// Un fichero nuevo cambia la fecha de su carpeta y solo esa se relee.
+	writeTree(t, root, "src/util.go")
+	later := time.Now().Add(time.Second)
+	if err := os.Chtimes(filepath.Join(root, "src"), later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := ix.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	want = []string{"docs", "docs/report.pdf", "src", "src/main.go", "src/util.go"}
+	if got := rels(ix.Entries()); !reflect.DeepEqual(got, want) {
+		t.Fatalf("tras añadir: Entries() = %q, want %q", got, want)
+	}
+
+	// El índice guardado se recupera en otro arranque.
+	reloaded := NewIndex([]string{root}, DefaultIgnore, cache)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := rels(reloaded.Entries()); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Load: Entries() = %q, want %q", got, want)
+	}
+
+	// Con otras exclusiones el índice guardado no vale.
+	other := NewIndex([]string{root}, nil, cache)
+	if err := other.Load(); err != nil || len(other.Entries()) != 0 {
+		t.Fatalf("Load con otras exclusiones: %d entradas, %v", len(other.Entries()), err)
+	}
+}
@@ -1,14 +1,20 @@
 package logic
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 
+	"fyne.io/fyne/v2"
+	"github.com/adelylria/GoFinder/logic/common"
 	"github.com/adelylria/GoFinder/models"
 )
 
+// AppFinder descubre las aplicaciones instaladas. Find llama a found por
+// cada app según la encuentra (nunca en paralelo) y se detiene con
+// ctx.Err() si se cancela el contexto.
 type AppFinder interface {
-	Find() []models.Application
+	Find(ctx context.Context, found func(models.Application)) error
 }
 
 var appFinders = make(map[string]AppFinder)
@@ -17,11 +23,31 @@ func RegisterAppFinder(os string, finder AppFinder) {
 	appFinders[os] = finder
 }
 
-func FindApplications() []models.Application {
+func FindApplications(ctx context.Context, found func(models.Application)) error {
 	finder, exists := appFinders[runtime.GOOS]
 	if !exists {
-		fmt.Printf("Sistema operativo no soportado: %s\n", runtime.GOOS)
-		return []models.Application{}
+		return fmt.Errorf("sistema operativo no soportado: %s", runtime.GOOS)
 	}
-	return finder.Find()
+	return finder.Find(ctx, found)
+}
+
+// CachedAppIcon devuelve el icono de la app solo si ya está en la caché de
+// memoria, sin tocar disco; útil desde el hilo de la UI.
+func CachedAppIcon(app models.Application) (fyne.Resource, bool) {
+	return common.CachePeek(common.IconCacheKey(app.IconPath, app.IconIdx))
+}
+
+// IconCacheStats devuelve los contadores de la caché de iconos en memoria.
+func IconCacheStats() common.IconCacheStats {
+	return common.CacheStats()
+}
+
+// LoadAppIndex devuelve las apps guardadas en el último escaneo cuyo fichero
+// de origen no ha cambiado, para mostrarlas antes de que termine el nuevo.
+func LoadAppIndex() ([]models.Application, error) {
+	return common.LoadAppIndex()
+}
+
+func SaveAppIndex(apps []models.Application) error {
+	return common.SaveAppIndex(apps)
 }
@@ -5,6 +5,7 @@ package logic
 import (
 	"fyne.io/fyne/v2"
 	"github.com/adelylria/GoFinder/logic/common"
+	"github.com/adelylria/GoFinder/logic/ubuntu"
 	"github.com/adelylria/GoFinder/models"
 )
 
@@ -12,12 +13,23 @@ func LoadAppIcon(app models.Application) fyne.Resource {
 	if app.IconPath == "" {
 		return nil
 	}
-	if cached, ok := common.CacheGet(app.IconPath); ok {
+	cacheKey := common.IconCacheKey(app.IconPath, app.IconIdx)
+	if cached, ok := common.CacheGet(cacheKey); ok {
+		return cached
+	}
+	if cached, ok := common.DiskCacheGet(app.IconPath, app.IconIdx, ubuntu.IconSize); ok {
+		common.CacheSet(cacheKey, cached)
 		return cached
 	}
 	res := common.LoadImageFileToResource(app.IconPath, app.Name)
 	if res != nil {
-		common.CacheSet(app.IconPath, res)
+		common.CacheSet(cacheKey, res)
+		common.DiskCacheSet(app.IconPath, app.IconIdx, ubuntu.IconSize, res)
 	}
 	return res
 }
+
+// WarmIconCache precarga en memoria los iconos guardados en disco.
+func WarmIconCache() {
+	common.WarmIconCache(ubuntu.IconSize)
+}
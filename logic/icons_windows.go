@@ -3,7 +3,6 @@
 package logic
 
 import (
-	"fmt"
 	"path/filepath"
 	"strings"
 
@@ -13,30 +12,47 @@ import (
 	"github.com/adelylria/GoFinder/models"
 )
 
+// iconCacheSize es el tamaño de los iconos grandes que devuelve ExtractIconEx.
+const iconCacheSize = 32
+
 func LoadAppIcon(app models.Application) fyne.Resource {
-	cacheKey := fmt.Sprintf("%s|%d", app.IconPath, app.IconIdx)
+	cacheKey := common.IconCacheKey(app.IconPath, app.IconIdx)
 	if cached, ok := common.CacheGet(cacheKey); ok {
 		return cached
 	}
+	if cached, ok := common.DiskCacheGet(app.IconPath, app.IconIdx, iconCacheSize); ok {
+		common.CacheSet(cacheKey, cached)
+		return cached
+	}
 
 	if res := loadFromIconPath(app); res != nil {
-		common.CacheSet(cacheKey, res)
+		storeAppIcon(cacheKey, app, res)
 		return res
 	}
 
 	if res := extractFromIconPath(app); res != nil {
-		common.CacheSet(cacheKey, res)
+		storeAppIcon(cacheKey, app, res)
 		return res
 	}
 
 	if res := extractFromExec(app); res != nil {
-		common.CacheSet(cacheKey, res)
+		storeAppIcon(cacheKey, app, res)
 		return res
 	}
 
 	return nil
 }
 
+func storeAppIcon(cacheKey string, app models.Application, res fyne.Resource) {
+	common.CacheSet(cacheKey, res)
+	common.DiskCacheSet(app.IconPath, app.IconIdx, iconCacheSize, res)
+}
+
+// WarmIconCache precarga en memoria los iconos guardados en disco.
+func WarmIconCache() {
+	common.WarmIconCache(iconCacheSize)
+}
+
 func loadFromIconPath(app models.Application) fyne.Resource {
 	if app.IconPath == "" {
 		return nil
@@ -0,0 +1,16 @@
+//go:build linux
+
+package logic
+
+import (
+	"github.com/adelylria/GoFinder/logic/ubuntu"
+	"github.com/adelylria/GoFinder/models"
+)
+
+var recentFiles = ubuntu.NewRecentList(ubuntu.RecentFilesPath())
+
+// RecentFiles devuelve los documentos recientes del escritorio, los más
+// recientes primero.
+func RecentFiles() ([]models.RecentFile, error) {
+	return recentFiles.Files()
+}
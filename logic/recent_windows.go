@@ -0,0 +1,10 @@
+//go:build windows
+
+package logic
+
+import "github.com/adelylria/GoFinder/models"
+
+// RecentFiles no tiene equivalente en Windows: no devuelve ninguno.
+func RecentFiles() ([]models.RecentFile, error) {
+	return nil, nil
+}
@@ -4,8 +4,14 @@
 package logic
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
 	"os/exec"
+	"path/filepath"
 
+	"github.com/adelylria/GoFinder/core/logger"
+	"github.com/adelylria/GoFinder/logic/ubuntu"
 	"github.com/adelylria/GoFinder/models"
 )
 
@@ -13,6 +19,79 @@ func RunApplication(app models.Application) error {
 	if app.Exec == "" {
 		return nil
 	}
-	cmd := exec.Command(app.Exec)
-	return cmd.Start()
+
+	argv, err := ubuntu.ParseExec(app)
+	if err != nil {
+		return fmt.Errorf("%s: %w", app.Exec, err)
+	}
+	if app.Terminal {
+		argv = ubuntu.TerminalCommand(argv)
+	}
+
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Dir = app.Path
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// OpenPath abre path con la aplicación predeterminada.
+func OpenPath(path string) error {
+	return startDetached("xdg-open", path)
+}
+
+// OpenPathWith abre path con app. Si su Exec no admite ficheros, lo abre
+// con la aplicación predeterminada.
+func OpenPathWith(app models.Application, path string) error {
+	argv, err := ubuntu.ParseExecFile(app, path)
+	if errors.Is(err, ubuntu.ErrNoFileCode) {
+		return OpenPath(path)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", app.Exec, err)
+	}
+	if app.Terminal {
+		argv = ubuntu.TerminalCommand(argv)
+	}
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Dir = app.Path
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// RevealPath muestra path seleccionado en el gestor de archivos. Si no hay
+// ninguno que implemente org.freedesktop.FileManager1, abre su carpeta. La
+// respuesta de D-Bus se espera en segundo plano para no bloquear la UI.
+func RevealPath(path string) error {
+	uri := (&url.URL{Scheme: "file", Path: path}).String()
+	cmd := exec.Command("dbus-send", "--session", "--print-reply",
+		"--dest=org.freedesktop.FileManager1", "--type=method_call",
+		"/org/freedesktop/FileManager1", "org.freedesktop.FileManager1.ShowItems",
+		"array:string:"+uri, "string:")
+	if err := cmd.Start(); err != nil {
+		return OpenPath(filepath.Dir(path))
+	}
+	logger.GoSafe(func() {
+		if cmd.Wait() == nil {
+			return
+		}
+		if err := OpenPath(filepath.Dir(path)); err != nil {
+			fmt.Printf("Error abriendo %s: %v\n", filepath.Dir(path), err)
+		}
+	})
+	return nil
+}
+
+func startDetached(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
 }
@@ -4,7 +4,9 @@
 package logic
 
 import (
+	"os/exec"
 	"path/filepath"
+	"syscall"
 
 	"github.com/adelylria/GoFinder/models"
 	"golang.org/x/sys/windows"
@@ -32,3 +34,35 @@ func RunApplication(app models.Application) error {
 	const showNormal = 1
 	return windows.ShellExecute(0, verb, file, nil, cwd, showNormal)
 }
+
+// OpenPath abre path con la aplicación predeterminada.
+func OpenPath(path string) error {
+	verb, err := windows.UTF16PtrFromString("open")
+	if err != nil {
+		return err
+	}
+	file, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+	const showNormal = 1
+	return windows.ShellExecute(0, verb, file, nil, nil, showNormal)
+}
+
+// OpenPathWith abre path con la aplicación predeterminada: en Windows no se
+// registra con qué app se abrió cada documento.
+func OpenPathWith(app models.Application, path string) error {
+	return OpenPath(path)
+}
+
+// RevealPath abre el Explorador con path seleccionado. La línea se pasa
+// tal cual porque explorer no sigue las reglas de comillas de Go.
+func RevealPath(path string) error {
+	cmd := exec.Command("explorer")
+	cmd.SysProcAttr = &syscall.SysProcAttr{CmdLine: `explorer /select,"` + path + `"`}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
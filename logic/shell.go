@@ -0,0 +1,71 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// commandStderrMax es cuánto del final de stderr se guarda de cada orden.
+const commandStderrMax = 4 << 10
+
+// CommandError es una orden de shell que terminó con error.
+type CommandError struct {
+	ExitCode int
+	Stderr   string // final de la salida de error
+	Err      error
+}
+
+func (e *CommandError) Error() string {
+	if line := e.LastLine(); line != "" {
+		return fmt.Sprintf("%v: %s", e.Err, line)
+	}
+	return e.Err.Error()
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// LastLine devuelve la última línea no vacía de stderr, que suele ser la
+// que explica el fallo.
+func (e *CommandError) LastLine() string {
+	lines := strings.Split(strings.TrimSpace(e.Stderr), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// waitCommand ejecuta cmd hasta que termina y convierte un código de salida
+// distinto de cero en un *CommandError.
+func waitCommand(cmd *exec.Cmd) error {
+	stderr := &tailBuffer{max: commandStderrMax}
+	cmd.Stderr = stderr
+	if home, err := os.UserHomeDir(); err == nil {
+		cmd.Dir = home
+	}
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &CommandError{ExitCode: exitErr.ExitCode(), Stderr: stderr.String(), Err: err}
+	}
+	return err
+}
+
+// tailBuffer guarda solo los últimos max bytes escritos.
+type tailBuffer struct {
+	max int
+	buf []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.buf = append(b.buf, p...)
+	if extra := len(b.buf) - b.max; extra > 0 {
+		b.buf = append(b.buf[:0], b.buf[extra:]...)
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	return string(b.buf)
+}
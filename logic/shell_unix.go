@@ -0,0 +1,52 @@
+//go:build linux
+
+package logic
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/adelylria/GoFinder/logic/ubuntu"
+)
+
+func userShell() string {
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return shell
+	}
+	return "/bin/sh"
+}
+
+// RunCommand ejecuta command con el shell del usuario, sin terminal y en su
+// propia sesión, y espera a que termine.
+func RunCommand(command string) error {
+	cmd := exec.Command(userShell(), "-c", command)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	return waitCommand(cmd)
+}
+
+// RunCommandInTerminal abre command en terminal, o en el emulador que se
+// detecte si está vacío, y deja el shell abierto al terminar. No espera.
+func RunCommandInTerminal(command, terminal string) error {
+	shell := userShell()
+	argv := []string{shell, "-c", command + "; exec " + shell}
+	if fields := strings.Fields(terminal); len(fields) > 0 {
+		argv = append(fields, argv...)
+	} else if wrapped := ubuntu.TerminalCommand(argv); len(wrapped) > len(argv) {
+		argv = wrapped
+	} else {
+		return errors.New("no se encontró un emulador de terminal")
+	}
+
+	cmd := exec.Command(argv[0], argv[1:]...)
+	if home, err := os.UserHomeDir(); err == nil {
+		cmd.Dir = home
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
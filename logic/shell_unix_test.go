@@ -0,0 +1,23 @@
+//go:build linux
+
+package logic
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRunCommand(t *testing.T) {
+	if err := RunCommand("true"); err != nil {
+		t.Fatalf("RunCommand(true): %v", err)
+	}
+
+	err := RunCommand("echo primero >&2; echo 'no existe' >&2; exit 3")
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("RunCommand: err = %v, se esperaba *CommandError", err)
+	}
+	if cmdErr.ExitCode != 3 || cmdErr.LastLine() != "no existe" {
+		t.Fatalf("CommandError = %d %q", cmdErr.ExitCode, cmdErr.LastLine())
+	}
+}
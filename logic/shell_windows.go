@@ -0,0 +1,49 @@
+//go:build windows
+
+package logic
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// RunCommand ejecuta command con cmd.exe, sin ventana, y espera a que
+// termine. La línea se pasa tal cual porque cmd no sigue las reglas de
+// comillas de Go.
+func RunCommand(command string) error {
+	cmd := exec.Command("cmd")
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		CmdLine:       "cmd /C " + command,
+		HideWindow:    true,
+		CreationFlags: windows.CREATE_NO_WINDOW,
+	}
+	return waitCommand(cmd)
+}
+
+// RunCommandInTerminal abre command en una consola nueva, o dentro de
+// terminal (por ejemplo "wt") si no está vacío, y la deja abierta. No
+// espera.
+func RunCommandInTerminal(command, terminal string) error {
+	var cmd *exec.Cmd
+	if fields := strings.Fields(terminal); len(fields) > 0 {
+		cmd = exec.Command(fields[0], append(fields[1:], "cmd", "/K", command)...)
+	} else {
+		cmd = exec.Command("cmd")
+		cmd.SysProcAttr = &syscall.SysProcAttr{
+			CmdLine:       "cmd /K " + command,
+			CreationFlags: windows.CREATE_NEW_CONSOLE,
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		cmd.Dir = home
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
@@ -0,0 +1,136 @@
+package ubuntu
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/adelylria/GoFinder/models"
+)
+
+// shouldShowEntry applies the visibility rules of the Desktop Entry spec:
+// only Type=Application entries are launchable, and NoDisplay, Hidden,
+// OnlyShowIn/NotShowIn and TryExec can each hide an entry from the menu.
+func shouldShowEntry(app models.Application) bool {
+	if app.Type != "Application" || app.NoDisplay || app.Hidden {
+		return false
+	}
+	if !showInDesktop(app.OnlyShowIn, app.NotShowIn, currentDesktops()) {
+		return false
+	}
+	return app.TryExec == "" || tryExecAvailable(app.TryExec)
+}
+
+// showInDesktop reports whether an entry with the given OnlyShowIn/NotShowIn
+// lists should be visible in any of the current desktops.
+func showInDesktop(onlyShowIn, notShowIn, desktops []string) bool {
+	for _, desktop := range desktops {
+		if containsFold(notShowIn, desktop) {
+			return false
+		}
+	}
+	if len(onlyShowIn) == 0 {
+		return true
+	}
+	for _, desktop := range desktops {
+		if containsFold(onlyShowIn, desktop) {
+			return true
+		}
+	}
+	return false
+}
+
+// currentDesktops returns the colon-separated list from XDG_CURRENT_DESKTOP.
+func currentDesktops() []string {
+	value := os.Getenv("XDG_CURRENT_DESKTOP")
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ":")
+}
+
+func tryExecAvailable(tryExec string) bool {
+	if filepath.IsAbs(tryExec) {
+		info, err := os.Stat(tryExec)
+		return err == nil && !info.IsDir() && info.Mode()&0o111 != 0
+	}
+	_, err := exec.LookPath(tryExec)
+	return err == nil
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// unescapeValue decodes the escape sequences allowed in string values:
+// \s, \n, \t, \r and \\.
+func unescapeValue(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	var b strings.Builder
+	b.Grow(len(value))
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 's':
+			b.WriteByte(' ')
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '\\':
+			b.WriteByte('\\')
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
+
+// splitList splits a semicolon-separated list value, honouring the "\;"
+// escape and dropping empty items (the trailing ";" is optional).
+func splitList(value string) []string {
+	var items []string
+	var current strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value):
+			if value[i+1] == ';' {
+				current.WriteByte(';')
+			} else {
+				current.WriteString(value[i : i+2])
+			}
+			i++
+		case value[i] == ';':
+			if item := unescapeValue(strings.TrimSpace(current.String())); item != "" {
+				items = append(items, item)
+			}
+			current.Reset()
+		default:
+			current.WriteByte(value[i])
+		}
+	}
+	if item := unescapeValue(strings.TrimSpace(current.String())); item != "" {
+		items = append(items, item)
+	}
+	return items
+}
+
+// parseBool accepts the spec's "true"/"false" and the legacy "1"/"0".
+func parseBool(value string) bool {
+	return value == "true" || value == "1"
+}
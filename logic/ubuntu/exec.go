@@ -0,0 +1,215 @@
+package ubuntu
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/adelylria/GoFinder/models"
+)
+
+var (
+	ErrEmptyExec         = errors.New("Exec vacío")
+	ErrUnterminatedQuote = errors.New("Exec mal formado: comillas sin cerrar")
+	ErrTrailingEscape    = errors.New("Exec mal formado: barra invertida al final")
+	ErrInvalidFieldCode  = errors.New("Exec mal formado: código de campo no válido")
+	ErrNoFileCode        = errors.New("Exec no admite ficheros")
+)
+
+// execArg is a single argument of an Exec line before field-code expansion.
+type execArg struct {
+	value  string
+	quoted bool
+}
+
+// execFile is the file passed to the %f, %F, %u and %U field codes.
+type execFile struct {
+	path string
+	used bool
+}
+
+// ParseExec splits app.Exec into an argv following the quoting rules of the
+// Desktop Entry spec and expands its field codes. Launching an app passes
+// no files or URLs, so %f, %F, %u and %U expand to nothing.
+func ParseExec(app models.Application) ([]string, error) {
+	return parseExec(app, nil)
+}
+
+// ParseExecFile is like ParseExec but opens path: %f and %F expand to the
+// path and %u and %U to its file:// URI. It returns ErrNoFileCode if the
+// Exec line takes no files.
+func ParseExecFile(app models.Application, path string) ([]string, error) {
+	file := &execFile{path: path}
+	argv, err := parseExec(app, file)
+	if err == nil && !file.used {
+		return nil, ErrNoFileCode
+	}
+	return argv, err
+}
+
+func parseExec(app models.Application, file *execFile) ([]string, error) {
+	args, err := splitExec(app.Exec)
+	if err != nil {
+		return nil, err
+	}
+
+	var argv []string
+	for _, arg := range args {
+		expanded, err := expandFieldCodes(arg, app, file)
+		if err != nil {
+			return nil, err
+		}
+		argv = append(argv, expanded...)
+	}
+	if len(argv) == 0 || argv[0] == "" {
+		return nil, ErrEmptyExec
+	}
+	return argv, nil
+}
+
+// arg returns what a file field code expands to, or "" without a file.
+func (f *execFile) arg(code byte) string {
+	if f == nil {
+		return ""
+	}
+	f.used = true
+	if code == 'u' || code == 'U' {
+		return (&url.URL{Scheme: "file", Path: f.path}).String()
+	}
+	return f.path
+}
+
+func splitExec(line string) ([]execArg, error) {
+	var (
+		args    []execArg
+		current strings.Builder
+		started bool
+		quoted  bool
+		inQuote bool
+	)
+
+	flush := func() {
+		if started {
+			args = append(args, execArg{value: current.String(), quoted: quoted})
+		}
+		current.Reset()
+		started, quoted = false, false
+	}
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '"':
+			inQuote = !inQuote
+			started, quoted = true, true
+		case c == '\\':
+			if i+1 == len(line) {
+				return nil, ErrTrailingEscape
+			}
+			next := line[i+1]
+			if inQuote && !strings.ContainsRune("\"`$\\", rune(next)) {
+				// Escape no reservado: se conserva literal por tolerancia.
+				current.WriteByte(c)
+				started = true
+				continue
+			}
+			current.WriteByte(next)
+			started = true
+			i++
+		case !inQuote && (c == ' ' || c == '\t'):
+			flush()
+		default:
+			current.WriteByte(c)
+			started = true
+		}
+	}
+	if inQuote {
+		return nil, ErrUnterminatedQuote
+	}
+	flush()
+	return args, nil
+}
+
+func expandFieldCodes(arg execArg, app models.Application, file *execFile) ([]string, error) {
+	if !arg.quoted {
+		switch arg.value {
+		case "%f", "%F", "%u", "%U":
+			if value := file.arg(arg.value[1]); value != "" {
+				return []string{value}, nil
+			}
+			return nil, nil
+		case "%i":
+			if app.Icon == "" {
+				return nil, nil
+			}
+			return []string{"--icon", app.Icon}, nil
+		}
+	}
+
+	var b strings.Builder
+	hadCodes := false
+	for i := 0; i < len(arg.value); i++ {
+		c := arg.value[i]
+		if c != '%' {
+			b.WriteByte(c)
+			continue
+		}
+		if i+1 == len(arg.value) {
+			return nil, fmt.Errorf("%w: %% al final de %q", ErrInvalidFieldCode, arg.value)
+		}
+		i++
+		hadCodes = true
+		switch code := arg.value[i]; code {
+		case '%':
+			b.WriteByte('%')
+		case 'f', 'F', 'u', 'U':
+			b.WriteString(file.arg(code))
+		case 'd', 'D', 'n', 'N', 'v', 'm':
+			// Los códigos obsoletos se eliminan.
+		case 'i':
+			b.WriteString(app.Icon)
+		case 'c':
+			b.WriteString(app.Name)
+		case 'k':
+			b.WriteString(app.SourcePath)
+		default:
+			return nil, fmt.Errorf("%w: %%%c", ErrInvalidFieldCode, code)
+		}
+	}
+
+	value := b.String()
+	if value == "" && hadCodes && !arg.quoted {
+		return nil, nil
+	}
+	return []string{value}, nil
+}
+
+// terminalEmulators lists the fallbacks tried when TERMINAL is unset, with
+// the flag each one uses to run a command.
+var terminalEmulators = []struct {
+	name string
+	flag string
+}{
+	{"x-terminal-emulator", "-e"},
+	{"gnome-terminal", "--"},
+	{"konsole", "-e"},
+	{"xfce4-terminal", "-x"},
+	{"xterm", "-e"},
+}
+
+// TerminalCommand wraps argv so it runs inside a terminal emulator, as
+// required by entries with Terminal=true.
+func TerminalCommand(argv []string) []string {
+	if terminal := os.Getenv("TERMINAL"); terminal != "" {
+		return append([]string{terminal, "-e"}, argv...)
+	}
+	for _, term := range terminalEmulators {
+		if path, err := exec.LookPath(term.name); err == nil {
+			return append([]string{path, term.flag}, argv...)
+		}
+	}
+	return argv
+}
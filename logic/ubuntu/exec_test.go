@@ -0,0 +1,107 @@
+package ubuntu
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adelylria/GoFinder/models"
+)
+
+func TestParseExec(t *testing.T) {
+	tests := []struct {
+		name string
+		line string // tal y como aparece en el fichero .desktop
+		want []string
+	}{
+		{"firefox", `firefox %u`, []string{"firefox"}},
+		{"chrome", `/usr/bin/google-chrome-stable %U`, []string{"/usr/bin/google-chrome-stable"}},
+		{"vscode snap", `env BAMF_DESKTOP_FILE_HINT=/var/lib/snapd/desktop/applications/code_code.desktop /snap/bin/code --force-user-env %F`,
+			[]string{"env", "BAMF_DESKTOP_FILE_HINT=/var/lib/snapd/desktop/applications/code_code.desktop", "/snap/bin/code", "--force-user-env"}},
+		{"flatpak", `/usr/bin/flatpak run --branch=stable --arch=x86_64 --command=telegram-desktop --file-forwarding org.telegram.desktop -- @@u %u @@`,
+			[]string{"/usr/bin/flatpak", "run", "--branch=stable", "--arch=x86_64", "--command=telegram-desktop", "--file-forwarding", "org.telegram.desktop", "--", "@@u", "@@"}},
+		{"quoted path", `"/opt/My App/bin/app" --flag`, []string{"/opt/My App/bin/app", "--flag"}},
+		{"sh -c", `sh -c "echo \\"hola\\" \\$HOME"`, []string{"sh", "-c", `echo "hola" $HOME`}},
+		{"literal backslash", `app "C:\\\\dir"`, []string{"app", `C:\dir`}},
+		{"icon", `gimp-2.10 %i %U`, []string{"gimp-2.10", "--icon", "gimp"}},
+		{"name and file", `app --title=%c --desktop=%k`, []string{"app", "--title=GIMP", "--desktop=/usr/share/applications/gimp.desktop"}},
+		{"percent", `printf 100%%`, []string{"printf", "100%"}},
+		{"deprecated", `xmms %m %N`, []string{"xmms"}},
+		{"empty quoted arg", `app ""`, []string{"app", ""}},
+		{"tabs", "steam\t%U", []string{"steam"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := parseExecFixture(t, tt.line)
+			got, err := ParseExec(app)
+			if err != nil {
+				t.Fatalf("ParseExec(%q) error: %v", app.Exec, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParseExec(%q) = %#v, want %#v", app.Exec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExecErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want error
+	}{
+		{"unterminated", `sh -c "echo`, ErrUnterminatedQuote},
+		{"trailing escape", `app \\`, ErrTrailingEscape},
+		{"unknown code", `app %z`, ErrInvalidFieldCode},
+		{"lone percent", `app 50%`, ErrInvalidFieldCode},
+		{"only field codes", `%U`, ErrEmptyExec},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := parseExecFixture(t, tt.line)
+			if _, err := ParseExec(app); !errors.Is(err, tt.want) {
+				t.Fatalf("ParseExec(%q) error = %v, want %v", app.Exec, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExecFile(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want []string
+		err  error
+	}{
+		{"uri", `evince %U`, []string{"evince", "file:///tmp/my%20report.pdf"}, nil},
+		{"path", `gedit --new-window %f`, []string{"gedit", "--new-window", "/tmp/my report.pdf"}, nil},
+		{"embedded", `app --open=%f`, []string{"app", "--open=/tmp/my report.pdf"}, nil},
+		{"no file code", `gimp-2.10 %i`, nil, ErrNoFileCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := parseExecFixture(t, tt.line)
+			got, err := ParseExecFile(app, "/tmp/my report.pdf")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ParseExecFile(%q) error = %v, want %v", app.Exec, err, tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParseExecFile(%q) = %#v, want %#v", app.Exec, got, tt.want)
+			}
+		})
+	}
+}
+
+// parseExecFixture pasa la línea Exec por parseDesktopFile para aplicar
+// también el desescapado de valores del fichero.
+func parseExecFixture(t *testing.T, line string) models.Application {
+	t.Helper()
+	path := writeDesktopFile(t, t.TempDir(), "gimp.desktop",
+		"[Desktop Entry]\nType=Application\nName=GIMP\nIcon=gimp\nExec="+line+"\n")
+	app, _ := parseDesktopFile(path)
+	app.SourcePath = "/usr/share/applications/gimp.desktop"
+	return app
+}
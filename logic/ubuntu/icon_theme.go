@@ -0,0 +1,450 @@
+package ubuntu
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/adelylria/GoFinder/core/configuration"
+	"github.com/adelylria/GoFinder/logic/common"
+)
+
+const (
+	// IconSize es el tamaño (en píxeles lógicos) que se pide al tema para
+	// los iconos de la lista de resultados.
+	IconSize      = 48
+	fallbackTheme = "hicolor"
+)
+
+var iconExtensions = []string{".png", ".svg", ".xpm"}
+
+// iconDir is a subdirectory section of an index.theme file.
+type iconDir struct {
+	path      string
+	size      int
+	scale     int
+	minSize   int
+	maxSize   int
+	threshold int
+	kind      string
+}
+
+type iconTheme struct {
+	name    string
+	parents []string
+	dirs    []iconDir
+}
+
+// IconLookup resolves icon names to files following the freedesktop Icon
+// Theme spec. It is safe for concurrent use and caches both parsed themes
+// and directory listings, since the same dirs are probed for every app.
+// The lock only guards the maps: each theme and directory is read from
+// disk once, outside it, so the discovery workers don't wait on each other.
+type IconLookup struct {
+	theme    string
+	baseDirs []string
+
+	mu       sync.Mutex
+	themes   map[string]*lazy[*iconTheme]
+	listings map[string]*lazy[map[string]bool]
+	resolved map[string]string
+}
+
+// lazy guarda un valor que se calcula una sola vez.
+type lazy[T any] struct {
+	once  sync.Once
+	value T
+}
+
+// NewIconLookup creates a lookup for the given theme; an empty name uses
+// the theme configured in GoFinder or, failing that, the desktop's theme.
+func NewIconLookup(theme string) *IconLookup {
+	if theme == "" {
+		theme = currentIconTheme()
+	}
+	return &IconLookup{
+		theme:    theme,
+		baseDirs: iconBaseDirs(),
+		themes:   make(map[string]*lazy[*iconTheme]),
+		listings: make(map[string]*lazy[map[string]bool]),
+		resolved: make(map[string]string),
+	}
+}
+
+// Lookup returns the file for the Icon key of a desktop entry, or "" when
+// nothing matches. Absolute paths are returned as-is if they exist.
+func (l *IconLookup) Lookup(icon string, size, scale int) string {
+	if icon == "" {
+		return ""
+	}
+	if filepath.IsAbs(icon) {
+		if _, err := os.Stat(icon); err == nil {
+			return icon
+		}
+		return ""
+	}
+	// Algunos paquetes ponen la extensión en el nombre, algo que el
+	// estándar no permite pero que es habitual.
+	for _, ext := range iconExtensions {
+		icon = strings.TrimSuffix(icon, ext)
+	}
+
+	key := icon + "@" + strconv.Itoa(size) + "x" + strconv.Itoa(scale)
+	l.mu.Lock()
+	path, ok := l.resolved[key]
+	l.mu.Unlock()
+	if ok {
+		return path
+	}
+
+	// Dos workers pueden resolver el mismo nombre a la vez; dan el mismo
+	// resultado y las lecturas de disco ya se comparten.
+	path = l.lookup(icon, size, scale)
+	l.mu.Lock()
+	l.resolved[key] = path
+	l.mu.Unlock()
+	return path
+}
+
+func (l *IconLookup) lookup(icon string, size, scale int) string {
+	for _, name := range l.themeChain() {
+		if path := l.lookupInTheme(l.loadTheme(name), icon, size, scale); path != "" {
+			return path
+		}
+	}
+	return l.lookupFallback(icon)
+}
+
+// themeChain returns the configured theme followed by its Inherits chain
+// (depth-first) and hicolor, without repetitions.
+func (l *IconLookup) themeChain() []string {
+	var chain []string
+	seen := make(map[string]bool)
+	var visit func(name string)
+	visit = func(name string) {
+		if name == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		chain = append(chain, name)
+		if theme := l.loadTheme(name); theme != nil {
+			for _, parent := range theme.parents {
+				visit(parent)
+			}
+		}
+	}
+	visit(l.theme)
+	visit(fallbackTheme)
+	return chain
+}
+
+func (l *IconLookup) lookupInTheme(theme *iconTheme, icon string, size, scale int) string {
+	if theme == nil {
+		return ""
+	}
+	for _, dir := range theme.dirs {
+		if !dir.matchesSize(size, scale) {
+			continue
+		}
+		if path := l.findInDir(theme.name, dir.path, icon); path != "" {
+			return path
+		}
+	}
+
+	best, bestDistance := "", int(^uint(0)>>1)
+	for _, dir := range theme.dirs {
+		distance := dir.sizeDistance(size, scale)
+		if distance >= bestDistance {
+			continue
+		}
+		if path := l.findInDir(theme.name, dir.path, icon); path != "" {
+			best, bestDistance = path, distance
+		}
+	}
+	return best
+}
+
+func (l *IconLookup) findInDir(theme, subdir, icon string) string {
+	for _, base := range l.baseDirs {
+		dir := filepath.Join(base, theme, subdir)
+		if path := l.findFile(dir, icon); path != "" {
+			return path
+		}
+	}
+	return ""
+}
+
+// lookupFallback busca iconos sueltos fuera de cualquier tema, por ejemplo
+// en /usr/share/pixmaps.
+func (l *IconLookup) lookupFallback(icon string) string {
+	for _, base := range l.baseDirs {
+		if path := l.findFile(base, icon); path != "" {
+			return path
+		}
+	}
+	return ""
+}
+
+func (l *IconLookup) findFile(dir, icon string) string {
+	l.mu.Lock()
+	entry, ok := l.listings[dir]
+	if !ok {
+		entry = &lazy[map[string]bool]{}
+		l.listings[dir] = entry
+	}
+	l.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.value = make(map[string]bool)
+		if entries, err := os.ReadDir(dir); err == nil {
+			for _, e := range entries {
+				entry.value[e.Name()] = true
+			}
+		}
+	})
+	for _, ext := range iconExtensions {
+		if entry.value[icon+ext] {
+			return filepath.Join(dir, icon+ext)
+		}
+	}
+	return ""
+}
+
+func (l *IconLookup) loadTheme(name string) *iconTheme {
+	l.mu.Lock()
+	entry, ok := l.themes[name]
+	if !ok {
+		entry = &lazy[*iconTheme]{}
+		l.themes[name] = entry
+	}
+	l.mu.Unlock()
+
+	entry.once.Do(func() {
+		for _, base := range l.baseDirs {
+			if t, err := parseIndexTheme(filepath.Join(base, name, "index.theme")); err == nil {
+				t.name = name
+				entry.value = t
+				return
+			}
+		}
+	})
+	return entry.value
+}
+
+// parseIndexTheme reads the [Icon Theme] section and the sections of the
+// directories it lists.
+func parseIndexTheme(path string) (*iconTheme, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	sections := make(map[string]map[string]string)
+	var current map[string]string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if ok, name := parseSectionHeader(line); ok {
+			current = make(map[string]string)
+			sections[name] = current
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if key, value, ok := splitKeyValue(line); ok {
+			current[key] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	header := sections["Icon Theme"]
+	theme := &iconTheme{parents: splitCommaList(header["Inherits"])}
+	dirNames := append(splitCommaList(header["Directories"]), splitCommaList(header["ScaledDirectories"])...)
+	for _, name := range dirNames {
+		if section, ok := sections[name]; ok {
+			theme.dirs = append(theme.dirs, newIconDir(name, section))
+		}
+	}
+	return theme, nil
+}
+
+func newIconDir(path string, section map[string]string) iconDir {
+	size := atoiDefault(section["Size"], 0)
+	dir := iconDir{
+		path:      path,
+		size:      size,
+		scale:     atoiDefault(section["Scale"], 1),
+		minSize:   atoiDefault(section["MinSize"], size),
+		maxSize:   atoiDefault(section["MaxSize"], size),
+		threshold: atoiDefault(section["Threshold"], 2),
+		kind:      section["Type"],
+	}
+	if dir.kind == "" {
+		dir.kind = "Threshold"
+	}
+	return dir
+}
+
+func (d iconDir) matchesSize(size, scale int) bool {
+	if d.scale != scale {
+		return false
+	}
+	switch d.kind {
+	case "Fixed":
+		return d.size == size
+	case "Scalable":
+		return d.minSize <= size && size <= d.maxSize
+	default:
+		return d.size-d.threshold <= size && size <= d.size+d.threshold
+	}
+}
+
+func (d iconDir) sizeDistance(size, scale int) int {
+	want := size * scale
+	switch d.kind {
+	case "Fixed":
+		return abs(d.size*d.scale - want)
+	case "Scalable":
+		if want < d.minSize*d.scale {
+			return d.minSize*d.scale - want
+		}
+		if want > d.maxSize*d.scale {
+			return want - d.maxSize*d.scale
+		}
+		return 0
+	default:
+		if want < (d.size-d.threshold)*d.scale {
+			return d.minSize*d.scale - want
+		}
+		if want > (d.size+d.threshold)*d.scale {
+			return want - d.maxSize*d.scale
+		}
+		return 0
+	}
+}
+
+// iconBaseDirs devuelve los directorios base en orden: ~/.icons,
+// $XDG_DATA_DIRS/icons y /usr/share/pixmaps.
+func iconBaseDirs() []string {
+	dirs := []string{filepath.Join(os.Getenv("HOME"), ".icons")}
+	for _, dir := range common.XDGDataDirs() {
+		dirs = append(dirs, filepath.Join(dir, "icons"))
+	}
+	return append(dirs, "/usr/share/pixmaps")
+}
+
+// lastIconTheme es el tema del último escaneo completo. Refresh lo reutiliza
+// para no releer la configuración en cada lote del watcher; un cambio de tema
+// se aplica en el siguiente escaneo.
+var lastIconTheme struct {
+	sync.Mutex
+	name string
+}
+
+// scanIconTheme lee el tema actual y lo recuerda para refreshIconTheme.
+func scanIconTheme() string {
+	name := currentIconTheme()
+	lastIconTheme.Lock()
+	lastIconTheme.name = name
+	lastIconTheme.Unlock()
+	return name
+}
+
+// refreshIconTheme devuelve el tema del último escaneo, o lo lee si aún no
+// ha habido ninguno.
+func refreshIconTheme() string {
+	lastIconTheme.Lock()
+	name := lastIconTheme.name
+	lastIconTheme.Unlock()
+	if name == "" {
+		return scanIconTheme()
+	}
+	return name
+}
+
+// currentIconTheme returns the theme set in GoFinder's settings or, when
+// empty, the one configured for GTK or KDE.
+func currentIconTheme() string {
+	if cfg, err := configuration.Load(); err == nil && cfg.IconTheme != "" {
+		return cfg.IconTheme
+	}
+	return detectIconTheme()
+}
+
+func detectIconTheme() string {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if !filepath.IsAbs(configHome) {
+		configHome = filepath.Join(os.Getenv("HOME"), ".config")
+	}
+	candidates := []struct {
+		path    string
+		section string
+		key     string
+	}{
+		{filepath.Join(configHome, "gtk-4.0", "settings.ini"), "Settings", "gtk-icon-theme-name"},
+		{filepath.Join(configHome, "gtk-3.0", "settings.ini"), "Settings", "gtk-icon-theme-name"},
+		{filepath.Join(configHome, "kdeglobals"), "Icons", "Theme"},
+	}
+	for _, c := range candidates {
+		if theme := readIniValue(c.path, c.section, c.key); theme != "" {
+			return theme
+		}
+	}
+	return fallbackTheme
+}
+
+func readIniValue(path, section, key string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	inSection := false
+	for raw := range strings.SplitSeq(string(data), "\n") {
+		line := strings.TrimSpace(raw)
+		if ok, name := parseSectionHeader(line); ok {
+			inSection = name == section
+			continue
+		}
+		if !inSection {
+			continue
+		}
+		if k, v, ok := splitKeyValue(line); ok && k == key {
+			return strings.Trim(v, `"`)
+		}
+	}
+	return ""
+}
+
+func splitCommaList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func atoiDefault(value string, fallback int) int {
+	if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+		return n
+	}
+	return fallback
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
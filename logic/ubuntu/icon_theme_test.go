@@ -0,0 +1,78 @@
+package ubuntu
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestIconLookup(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("HOME", root)
+	t.Setenv("XDG_DATA_HOME", filepath.Join(root, "home"))
+	t.Setenv("XDG_DATA_DIRS", filepath.Join(root, "share"))
+	icons := filepath.Join(root, "share", "icons")
+
+	files := map[string]string{
+		"Child/index.theme": "[Icon Theme]\nName=Child\nInherits=Parent\nDirectories=32x32/apps\n\n" +
+			"[32x32/apps]\nSize=32\nType=Fixed\n",
+		"Child/32x32/apps/term.png": "",
+		"Parent/index.theme": "[Icon Theme]\nName=Parent\nDirectories=48x48/apps,scalable/apps\n\n" +
+			"[48x48/apps]\nSize=48\n\n[scalable/apps]\nSize=128\nMinSize=8\nMaxSize=512\nType=Scalable\n",
+		"Parent/48x48/apps/editor.png":   "",
+		"Parent/scalable/apps/paint.svg": "",
+		"hicolor/index.theme":            "[Icon Theme]\nName=Hicolor\nDirectories=48x48/apps\n\n[48x48/apps]\nSize=48\nType=Threshold\n",
+		"hicolor/48x48/apps/firefox.png": "",
+		"legacy.xpm":                     "",
+	}
+	for name, content := range files {
+		path := filepath.Join(icons, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lookup := NewIconLookup("Child")
+	tests := map[string]string{
+		"editor":                "Parent/48x48/apps/editor.png",
+		"paint":                 "Parent/scalable/apps/paint.svg",
+		"firefox":               "hicolor/48x48/apps/firefox.png",
+		"firefox.png":           "hicolor/48x48/apps/firefox.png",
+		"term":                  "Child/32x32/apps/term.png",
+		"legacy":                "legacy.xpm",
+		icons + "/legacy.xpm":   "legacy.xpm",
+		"missing":               "",
+		"/nonexistent/icon.png": "",
+	}
+	for icon, want := range tests {
+		if want != "" {
+			want = filepath.Join(icons, want)
+		}
+		if got := lookup.Lookup(icon, IconSize, 1); got != want {
+			t.Fatalf("Lookup(%q) = %q, want %q", icon, got, want)
+		}
+	}
+
+	// Los workers del escaneo comparten un IconLookup.
+	shared := NewIconLookup("Child")
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for icon, want := range tests {
+				if want != "" {
+					want = filepath.Join(icons, want)
+				}
+				if got := shared.Lookup(icon, IconSize, 1); got != want {
+					t.Errorf("concurrent Lookup(%q) = %q, want %q", icon, got, want)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -1,50 +1,128 @@
 package ubuntu
 
 import (
-	"fmt"
+	"context"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 
+	"github.com/adelylria/GoFinder/core/i18n"
 	"github.com/adelylria/GoFinder/logic/common"
 	"github.com/adelylria/GoFinder/models"
 )
 
 type LinuxAppFinder struct{}
 
-func (f LinuxAppFinder) Find() []models.Application {
-	fmt.Println("Buscando aplicaciones en Linux...")
-	return findLinuxApplications()
+// findWorkers es cuántos .desktop se leen y parsean en paralelo, que es la
+// parte cara; recorrer los directorios no lo es.
+var findWorkers = runtime.NumCPU()
+
+func (f LinuxAppFinder) Find(ctx context.Context, found func(models.Application)) error {
+	return findLinuxApplications(ctx, findWorkers, found)
+}
+
+type desktopJob struct {
+	path, id string
 }
 
-func findLinuxApplications() []models.Application {
-	var apps []models.Application
+// findLinuxApplications recorre los directorios en una sola goroutine, que
+// decide qué fichero gana cada ID, y reparte el parseo entre workers. found
+// se llama según se parsea cada app, nunca en paralelo.
+func findLinuxApplications(ctx context.Context, workers int, found func(models.Application)) error {
+	icons := NewIconLookup(scanIconTheme())
+	jobs := make(chan desktopJob, 4*workers)
+	var foundMu sync.Mutex
+	var wg sync.WaitGroup
 
-	for _, dir := range common.GetAppDirs() {
-		fmt.Println("Escaneando directorio:", dir)
-		filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-			if err != nil || info.IsDir() || !strings.HasSuffix(path, ".desktop") {
-				return nil
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				if app, ok := loadDesktopApp(job.path, job.id, icons); ok {
+					foundMu.Lock()
+					found(app)
+					foundMu.Unlock()
+				}
 			}
-			fmt.Println("Encontrado .desktop:", path)
+		}()
+	}
 
-			if app, ok := parseDesktopFile(path); ok {
-				fmt.Printf("  → %s -> %s\n", app.Name, app.Exec)
-				apps = append(apps, app)
+	err := walkDesktopFiles(ctx, common.GetAppDirs(), func(path, id string) {
+		jobs <- desktopJob{path: path, id: id}
+	})
+	close(jobs)
+	wg.Wait()
+	if err == nil {
+		err = ctx.Err()
+	}
+	return err
+}
+
+// walkDesktopFiles recorre los directorios por orden de precedencia y llama
+// a visit con el primer fichero de cada ID, aunque esté oculto: Hidden=true
+// "borra" las entradas de menor precedencia.
+func walkDesktopFiles(ctx context.Context, dirs []string, visit func(path, id string)) error {
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".desktop") {
+				return nil
 			}
+			id := common.DesktopFileID(dir, path)
+			if seen[id] {
+				return nil
+			}
+			seen[id] = true
+			visit(path, id)
 			return nil
 		})
+		if err != nil {
+			return err
+		}
 	}
-	return apps
+	return nil
+}
+
+// loadDesktopApp lee el fichero que gana para el ID dado y devuelve la app
+// solo si debe mostrarse.
+func loadDesktopApp(path, id string, icons *IconLookup) (models.Application, bool) {
+	app, ok := parseDesktopFile(path)
+	if !ok || !shouldShowEntry(app) {
+		return app, false
+	}
+	app.DesktopID = id
+	app.AssignStableID()
+	app.IconPath = icons.Lookup(app.Icon, IconSize, 1)
+	for i, action := range app.DesktopActions {
+		if action.Icon != "" {
+			app.DesktopActions[i].IconPath = icons.Lookup(action.Icon, IconSize, 1)
+		}
+	}
+	return app, true
 }
 
 func parseDesktopFile(path string) (models.Application, bool) {
 	app := models.NewApplication()
+	app.SourcePath = path
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return app, false
 	}
 
+	locales := localeCandidates(i18n.CurrentLocale())
+	localized := make(map[string]localizedValue)
+	actions := make(map[string]*parsedAction)
+	var action *parsedAction // sección [Desktop Action ...] actual
 	inDesktopEntry := false
 	lines := strings.SplitSeq(string(data), "\n")
 
@@ -56,20 +134,76 @@ func parseDesktopFile(path string) (models.Application, bool) {
 
 		if ok, name := parseSectionHeader(line); ok {
 			inDesktopEntry = (name == "Desktop Entry")
+			action = nil
+			if id, ok := strings.CutPrefix(name, "Desktop Action "); ok && actions[id] == nil {
+				action = &parsedAction{action: models.DesktopAction{ID: id}, localized: make(map[string]localizedValue)}
+				actions[id] = action
+			}
 			continue
 		}
 
+		key, value, ok := splitKeyValue(line)
+		if !ok {
+			continue
+		}
+		if action != nil {
+			action.apply(locales, key, value)
+			continue
+		}
 		if !inDesktopEntry {
 			continue
 		}
-
-		if key, value, ok := splitKeyValue(line); ok {
-			applyDesktopKey(&app, key, value)
+		if base, locale, ok := splitLocalizedKey(key); ok {
+			collectLocalizedValue(localized, locales, base, locale, value)
+			continue
 		}
+		applyDesktopKey(&app, key, value)
 	}
+	applyLocalizedValues(&app, localized)
+	app.DesktopActions = desktopActions(app.Actions, actions)
 	return app, common.IsValidApp(app)
 }
 
+type parsedAction struct {
+	action    models.DesktopAction
+	localized map[string]localizedValue
+}
+
+func (a *parsedAction) apply(locales []string, key, value string) {
+	if base, locale, ok := splitLocalizedKey(key); ok {
+		collectLocalizedValue(a.localized, locales, base, locale, value)
+		return
+	}
+	switch key {
+	case "Name":
+		setString(&a.action.Name, unescapeValue(value))
+	case "Exec":
+		setString(&a.action.Exec, unescapeValue(value))
+	case "Icon":
+		setString(&a.action.Icon, unescapeValue(value))
+	}
+}
+
+// desktopActions devuelve, en el orden de la clave Actions, las acciones
+// con sección propia, nombre y Exec; la especificación manda ignorar las
+// secciones que Actions no nombra.
+func desktopActions(ids []string, parsed map[string]*parsedAction) []models.DesktopAction {
+	var actions []models.DesktopAction
+	for _, id := range ids {
+		a := parsed[id]
+		if a == nil {
+			continue
+		}
+		if v, ok := a.localized["Name"]; ok && v.value != "" {
+			a.action.Name = unescapeValue(v.value)
+		}
+		if a.action.Name != "" && a.action.Exec != "" {
+			actions = append(actions, a.action)
+		}
+	}
+	return actions
+}
+
 func parseSectionHeader(line string) (bool, string) {
 	if len(line) > 2 && line[0] == '[' && line[len(line)-1] == ']' {
 		name := strings.TrimSpace(line[1 : len(line)-1])
@@ -90,17 +224,45 @@ func splitKeyValue(line string) (string, string, bool) {
 
 func applyDesktopKey(app *models.Application, key, value string) {
 	switch key {
+	case "Type":
+		setString(&app.Type, value)
 	case "Name":
-		if app.Name == "" {
-			app.Name = value
-		}
+		setString(&app.Name, unescapeValue(value))
+	case "GenericName":
+		setString(&app.GenericName, unescapeValue(value))
+	case "Comment":
+		setString(&app.Comment, unescapeValue(value))
 	case "Exec":
-		if app.Exec == "" {
-			app.Exec = value
-		}
+		setString(&app.Exec, unescapeValue(value))
+	case "TryExec":
+		setString(&app.TryExec, unescapeValue(value))
 	case "Icon":
-		if app.Icon == "" {
-			app.Icon = value
-		}
+		setString(&app.Icon, unescapeValue(value))
+	case "Path":
+		setString(&app.Path, unescapeValue(value))
+	case "StartupWMClass":
+		setString(&app.StartupWMClass, unescapeValue(value))
+	case "Keywords":
+		app.Keywords = splitList(value)
+	case "Categories":
+		app.Categories = splitList(value)
+	case "OnlyShowIn":
+		app.OnlyShowIn = splitList(value)
+	case "NotShowIn":
+		app.NotShowIn = splitList(value)
+	case "Actions":
+		app.Actions = splitList(value)
+	case "NoDisplay":
+		app.NoDisplay = parseBool(value)
+	case "Hidden":
+		app.Hidden = parseBool(value)
+	case "Terminal":
+		app.Terminal = parseBool(value)
+	}
+}
+
+func setString(field *string, value string) {
+	if *field == "" {
+		*field = value
 	}
 }
@@ -0,0 +1,341 @@
+package ubuntu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/adelylria/GoFinder/core/i18n"
+	"github.com/adelylria/GoFinder/models"
+)
+
+func writeDesktopFile(t testing.TB, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseDesktopFileMetadata(t *testing.T) {
+	path := writeDesktopFile(t, t.TempDir(), "editor.desktop", `[Desktop Entry]
+Type=Application
+Name=Text Editor
+GenericName=Editor
+Comment=Edit text\sfiles
+Keywords=text;plain\;notes;
+Categories=GNOME;GTK;Utility;
+Exec=editor %U
+Icon=editor
+Terminal=false
+Path=/tmp
+StartupWMClass=Editor
+Actions=new-window;
+
+[Desktop Action new-window]
+Name=New Window
+Exec=editor --new-window
+`)
+
+	app, ok := parseDesktopFile(path)
+	if !ok {
+		t.Fatal("expected valid entry")
+	}
+	if app.Name != "Text Editor" || app.Exec != "editor %U" {
+		t.Fatalf("unexpected name/exec: %q %q", app.Name, app.Exec)
+	}
+	if app.Comment != "Edit text files" {
+		t.Fatalf("Comment = %q", app.Comment)
+	}
+	if !reflect.DeepEqual(app.Keywords, []string{"text", "plain;notes"}) {
+		t.Fatalf("Keywords = %#v", app.Keywords)
+	}
+	if !reflect.DeepEqual(app.Categories, []string{"GNOME", "GTK", "Utility"}) {
+		t.Fatalf("Categories = %#v", app.Categories)
+	}
+	if !reflect.DeepEqual(app.Actions, []string{"new-window"}) {
+		t.Fatalf("Actions = %#v", app.Actions)
+	}
+	if app.Path != "/tmp" || app.StartupWMClass != "Editor" || app.Terminal {
+		t.Fatalf("unexpected metadata: %#v", app)
+	}
+}
+
+func TestShouldShowEntry(t *testing.T) {
+	t.Setenv("XDG_CURRENT_DESKTOP", "ubuntu:GNOME")
+
+	tests := map[string]struct {
+		content string
+		want    bool
+	}{
+		"application": {"Type=Application\nName=A\nExec=a\n", true},
+		"link":        {"Type=Link\nName=A\nURL=https://example.com\nExec=a\n", false},
+		"nodisplay":   {"Type=Application\nName=A\nExec=a\nNoDisplay=true\n", false},
+		"hidden":      {"Type=Application\nName=A\nExec=a\nHidden=true\n", false},
+		"onlyshowin":  {"Type=Application\nName=A\nExec=a\nOnlyShowIn=KDE;\n", false},
+		"onlygnome":   {"Type=Application\nName=A\nExec=a\nOnlyShowIn=GNOME;\n", true},
+		"notshowin":   {"Type=Application\nName=A\nExec=a\nNotShowIn=GNOME;\n", false},
+		"tryexec":     {"Type=Application\nName=A\nExec=a\nTryExec=/nonexistent/gofinder-app\n", false},
+	}
+
+	dir := t.TempDir()
+	for name, tt := range tests {
+		path := writeDesktopFile(t, dir, name+".desktop", "[Desktop Entry]\n"+tt.content)
+		app, _ := parseDesktopFile(path)
+		if got := shouldShowEntry(app); got != tt.want {
+			t.Fatalf("%s: shouldShowEntry = %v, want %v", name, got, tt.want)
+		}
+	}
+}
+
+func TestLocaleCandidates(t *testing.T) {
+	got := localeCandidates("ca_ES.UTF-8@valencia")
+	want := []string{"ca_ES@valencia", "ca_ES", "ca@valencia", "ca"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("localeCandidates = %#v, want %#v", got, want)
+	}
+}
+
+func TestParseDesktopFileLocalized(t *testing.T) {
+	t.Setenv("GOFINDER_LANG", "es_ES.UTF-8")
+	i18n.SetLanguage(i18n.Spanish)
+	defer i18n.SetLanguage(i18n.DetectLanguage())
+
+	path := writeDesktopFile(t, t.TempDir(), "files.desktop", `[Desktop Entry]
+Type=Application
+Name=Files
+Name[es]=Archivos
+Name[es_ES]=Archivos de España
+Name[ca]=Fitxers
+Comment=Access files
+Comment[es]=Acceder a archivos
+Keywords=folder;manager;
+Keywords[es]=carpeta;gestor;
+Exec=nautilus
+`)
+
+	app, ok := parseDesktopFile(path)
+	if !ok {
+		t.Fatal("expected valid entry")
+	}
+	if app.Name != "Archivos de España" || app.UntranslatedName != "Files" {
+		t.Fatalf("Name = %q, UntranslatedName = %q", app.Name, app.UntranslatedName)
+	}
+	if app.Comment != "Acceder a archivos" {
+		t.Fatalf("Comment = %q", app.Comment)
+	}
+	if !reflect.DeepEqual(app.Keywords, []string{"carpeta", "gestor"}) {
+		t.Fatalf("Keywords = %#v", app.Keywords)
+	}
+}
+
+func TestParseDesktopActions(t *testing.T) {
+	t.Setenv("GOFINDER_LANG", "es_ES.UTF-8")
+	i18n.SetLanguage(i18n.Spanish)
+	defer i18n.SetLanguage(i18n.DetectLanguage())
+
+	path := writeDesktopFile(t, t.TempDir(), "firefox.desktop", `[Desktop Entry]
+Type=Application
+Name=Firefox
+Exec=firefox %u
+Icon=firefox
+Actions=new-private-window;missing;no-exec;new-window;
+
+[Desktop Action new-window]
+Name=New Window
+Name[es]=Nueva ventana
+Exec=firefox --new-window %u
+
+[Desktop Action new-private-window]
+Name=New Private Window
+Exec=firefox --private-window %u
+Icon=firefox-private
+
+[Desktop Action no-exec]
+Name=Broken
+
+[Desktop Action unlisted]
+Name=Unlisted
+Exec=firefox --unlisted
+`)
+
+	app, ok := parseDesktopFile(path)
+	if !ok {
+		t.Fatal("expected valid entry")
+	}
+	// Las acciones siguen el orden de Actions y las secciones no alteran la
+	// entrada principal.
+	want := []models.DesktopAction{
+		{ID: "new-private-window", Name: "New Private Window", Exec: "firefox --private-window %u", Icon: "firefox-private"},
+		{ID: "new-window", Name: "Nueva ventana", Exec: "firefox --new-window %u"},
+	}
+	if !reflect.DeepEqual(app.DesktopActions, want) {
+		t.Fatalf("DesktopActions = %#v, want %#v", app.DesktopActions, want)
+	}
+	if app.Exec != "firefox %u" || app.Icon != "firefox" {
+		t.Fatalf("Exec = %q, Icon = %q", app.Exec, app.Icon)
+	}
+}
+
+func TestFindLinuxApplicationsPrecedence(t *testing.T) {
+	home := t.TempDir()
+	system := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", home)
+	t.Setenv("XDG_DATA_DIRS", system)
+	t.Setenv("XDG_CURRENT_DESKTOP", "")
+
+	userApps := filepath.Join(home, "applications")
+	systemApps := filepath.Join(system, "applications")
+	for _, dir := range []string{userApps, filepath.Join(systemApps, "kde")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entry := "[Desktop Entry]\nType=Application\nExec=app\nName="
+	writeDesktopFile(t, userApps, "editor.desktop", entry+"User Editor\n")
+	writeDesktopFile(t, userApps, "mail.desktop", entry+"Mail\nHidden=true\n")
+	writeDesktopFile(t, systemApps, "editor.desktop", entry+"System Editor\n")
+	writeDesktopFile(t, systemApps, "mail.desktop", entry+"Mail\n")
+	writeDesktopFile(t, systemApps, "kde/konsole.desktop", entry+"Konsole\n")
+
+	found := make(map[string]string)
+	for _, app := range findApps(t) {
+		found[app.ID] = app.Name
+	}
+
+	if found["desktop:editor.desktop"] != "User Editor" {
+		t.Fatalf("editor.desktop = %q, want user entry", found["desktop:editor.desktop"])
+	}
+	if _, ok := found["desktop:mail.desktop"]; ok {
+		t.Fatal("mail.desktop should be hidden by the user entry")
+	}
+	if found["desktop:kde-konsole.desktop"] != "Konsole" {
+		t.Fatalf("kde-konsole.desktop = %q", found["desktop:kde-konsole.desktop"])
+	}
+
+	// Los IDs no cambian entre escaneos.
+	for _, app := range findApps(t) {
+		if _, ok := found[app.ID]; !ok {
+			t.Fatalf("ID %q changed on rescan", app.ID)
+		}
+	}
+}
+
+func TestLinuxRefresh(t *testing.T) {
+	home := t.TempDir()
+	system := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", home)
+	t.Setenv("XDG_DATA_DIRS", system)
+	t.Setenv("XDG_CURRENT_DESKTOP", "")
+
+	userApps := filepath.Join(home, "applications")
+	systemApps := filepath.Join(system, "applications")
+	for _, dir := range []string{userApps, systemApps} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entry := "[Desktop Entry]\nType=Application\nExec=app\nName="
+	writeDesktopFile(t, systemApps, "editor.desktop", entry+"System Editor\n")
+	writeDesktopFile(t, userApps, "editor.desktop", entry+"User Editor\n")
+
+	finder := LinuxAppFinder{}
+	systemPath := filepath.Join(systemApps, "editor.desktop")
+	userPath := filepath.Join(userApps, "editor.desktop")
+
+	// Un cambio en el fichero del sistema no tapa al del usuario.
+	changes := finder.Refresh([]string{systemPath, filepath.Join(systemApps, "notes.txt")})
+	if len(changes) != 1 || changes[0].ID != "desktop:editor.desktop" || changes[0].App.Name != "User Editor" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+
+	// Al borrar el del usuario vuelve a verse el del sistema.
+	if err := os.Remove(userPath); err != nil {
+		t.Fatal(err)
+	}
+	changes = finder.Refresh([]string{userPath})
+	if len(changes) != 1 || changes[0].App == nil || changes[0].App.Name != "System Editor" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+
+	// Sin ningún fichero la app desaparece.
+	if err := os.Remove(systemPath); err != nil {
+		t.Fatal(err)
+	}
+	changes = finder.Refresh([]string{systemPath})
+	if len(changes) != 1 || changes[0].App != nil {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+}
+
+func findApps(t testing.TB) []models.Application {
+	t.Helper()
+	var apps []models.Application
+	err := LinuxAppFinder{}.Find(context.Background(), func(app models.Application) {
+		apps = append(apps, app)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return apps
+}
+
+func TestFindLinuxApplicationsCancel(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("XDG_DATA_DIRS", writeDesktopTree(t, 200))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	n := 0
+	err := findLinuxApplications(ctx, 4, func(models.Application) { n++ })
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if n != 0 {
+		t.Fatalf("found %d apps after cancel", n)
+	}
+}
+
+// writeDesktopTree crea un directorio de datos con n ficheros .desktop
+// repartidos en subdirectorios, como el de una distribución con muchas apps.
+func writeDesktopTree(t testing.TB, n int) string {
+	t.Helper()
+	root := t.TempDir()
+	for i := 0; i < n; i++ {
+		dir := filepath.Join(root, "applications", fmt.Sprintf("vendor%d", i%20))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		entry := fmt.Sprintf("[Desktop Entry]\nType=Application\nName=App %d\nName[es]=Aplicación %d\n"+
+			"GenericName=Tool\nComment=Synthetic entry\nExec=app%d %%U\nIcon=app%d\n"+
+			"Categories=Utility;Development;\nKeywords=one;two;three;\n", i, i, i, i)
+		writeDesktopFile(t, dir, fmt.Sprintf("app%d.desktop", i), entry)
+	}
+	return root
+}
+
+func benchmarkFind(b *testing.B, workers int) {
+	b.Setenv("XDG_DATA_HOME", b.TempDir())
+	b.Setenv("XDG_DATA_DIRS", writeDesktopTree(b, 5000))
+	b.Setenv("XDG_CURRENT_DESKTOP", "")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		n := 0
+		if err := findLinuxApplications(context.Background(), workers, func(models.Application) { n++ }); err != nil {
+			b.Fatal(err)
+		}
+		if n != 5000 {
+			b.Fatalf("found %d apps, want 5000", n)
+		}
+	}
+}
+
+func BenchmarkFind5kSerial(b *testing.B)   { benchmarkFind(b, 1) }
+func BenchmarkFind5kParallel(b *testing.B) { benchmarkFind(b, findWorkers) }
@@ -0,0 +1,84 @@
+package ubuntu
+
+import (
+	"strings"
+
+	"github.com/adelylria/GoFinder/models"
+)
+
+// localizedValue is the best translation seen so far for a key; rank is the
+// position of its locale in the candidate list (lower is better).
+type localizedValue struct {
+	rank  int
+	value string
+}
+
+// localeCandidates expands a POSIX locale into the lookup order defined by
+// the Desktop Entry spec: lang_COUNTRY@MODIFIER, lang_COUNTRY, lang@MODIFIER
+// and lang. The encoding part is ignored.
+func localeCandidates(locale string) []string {
+	modifier := ""
+	if at := strings.Index(locale, "@"); at >= 0 {
+		locale, modifier = locale[:at], locale[at+1:]
+	}
+	if dot := strings.Index(locale, "."); dot >= 0 {
+		locale = locale[:dot]
+	}
+	lang, country, _ := strings.Cut(locale, "_")
+	if lang == "" {
+		return nil
+	}
+
+	var candidates []string
+	if country != "" && modifier != "" {
+		candidates = append(candidates, lang+"_"+country+"@"+modifier)
+	}
+	if country != "" {
+		candidates = append(candidates, lang+"_"+country)
+	}
+	if modifier != "" {
+		candidates = append(candidates, lang+"@"+modifier)
+	}
+	return append(candidates, lang)
+}
+
+// splitLocalizedKey splits "Name[es_ES]" into ("Name", "es_ES").
+func splitLocalizedKey(key string) (string, string, bool) {
+	open := strings.IndexByte(key, '[')
+	if open <= 0 || !strings.HasSuffix(key, "]") {
+		return "", "", false
+	}
+	return key[:open], key[open+1 : len(key)-1], true
+}
+
+func collectLocalizedValue(localized map[string]localizedValue, locales []string, key, locale, value string) {
+	for rank, candidate := range locales {
+		if candidate != locale {
+			continue
+		}
+		if current, ok := localized[key]; !ok || rank < current.rank {
+			localized[key] = localizedValue{rank: rank, value: value}
+		}
+		return
+	}
+}
+
+// applyLocalizedValues overrides the translatable keys with the best match
+// for the current locale, keeping the untranslated name for searching.
+func applyLocalizedValues(app *models.Application, localized map[string]localizedValue) {
+	if v, ok := localized["Name"]; ok && v.value != "" {
+		if name := unescapeValue(v.value); name != app.Name {
+			app.UntranslatedName = app.Name
+			app.Name = name
+		}
+	}
+	if v, ok := localized["GenericName"]; ok {
+		app.GenericName = unescapeValue(v.value)
+	}
+	if v, ok := localized["Comment"]; ok {
+		app.Comment = unescapeValue(v.value)
+	}
+	if v, ok := localized["Keywords"]; ok {
+		app.Keywords = splitList(v.value)
+	}
+}
@@ -0,0 +1,133 @@
+package ubuntu
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/adelylria/GoFinder/logic/common"
+	"github.com/adelylria/GoFinder/models"
+)
+
+// xbelFile es el subconjunto de recently-used.xbel (formato XBEL con los
+// metadatos de la especificación Desktop Bookmark) que se usa.
+type xbelFile struct {
+	Bookmarks []xbelBookmark `xml:"bookmark"`
+}
+
+type xbelBookmark struct {
+	Href     string `xml:"href,attr"`
+	Modified string `xml:"modified,attr"`
+	MimeType struct {
+		Type string `xml:"type,attr"`
+	} `xml:"info>metadata>mime-type"`
+	Apps []xbelApp `xml:"info>metadata>applications>application"`
+}
+
+type xbelApp struct {
+	Name     string `xml:"name,attr"`
+	Modified string `xml:"modified,attr"`
+	// Timestamp (segundos Unix) es lo que escribían las versiones antiguas
+	// de GTK en lugar de Modified.
+	Timestamp int64 `xml:"timestamp,attr"`
+}
+
+// RecentFilesPath devuelve la ruta del registro de documentos recientes que
+// comparten GNOME y KDE.
+func RecentFilesPath() string {
+	return filepath.Join(common.XDGDataHome(), "recently-used.xbel")
+}
+
+// LoadRecentFiles lee el registro de path y devuelve los ficheros locales
+// que aún existen, los más recientes primero.
+func LoadRecentFiles(path string) ([]models.RecentFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc xbelFile
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	files := make([]models.RecentFile, 0, len(doc.Bookmarks))
+	for _, b := range doc.Bookmarks {
+		u, err := url.Parse(b.Href)
+		if err != nil || u.Scheme != "file" || u.Path == "" {
+			continue
+		}
+		if _, err := os.Stat(u.Path); err != nil {
+			continue
+		}
+		file := models.RecentFile{Path: u.Path, MimeType: b.MimeType.Type, Modified: parseXbelTime(b.Modified)}
+		// Cada app guarda cuándo lo abrió; se muestra la última.
+		var last time.Time
+		for _, app := range b.Apps {
+			when := parseXbelTime(app.Modified)
+			if when.IsZero() && app.Timestamp > 0 {
+				when = time.Unix(app.Timestamp, 0)
+			}
+			if file.AppName == "" || when.After(last) {
+				file.AppName, last = app.Name, when
+			}
+		}
+		if !last.IsZero() {
+			file.Modified = last
+		}
+		files = append(files, file)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Modified.After(files[j].Modified)
+	})
+	return files, nil
+}
+
+func parseXbelTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// RecentList guarda los ficheros de LoadRecentFiles y solo vuelve a leer el
+// registro cuando cambia su fecha de modificación.
+type RecentList struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	files   []models.RecentFile
+}
+
+func NewRecentList(path string) *RecentList {
+	return &RecentList{path: path}
+}
+
+// Files devuelve los documentos recientes; si el registro no existe, ninguno.
+func (r *RecentList) Files() ([]models.RecentFile, error) {
+	info, err := os.Stat(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if info.ModTime().Equal(r.modTime) {
+		return r.files, nil
+	}
+	files, err := LoadRecentFiles(r.path)
+	if err != nil {
+		return nil, err
+	}
+	r.modTime, r.files = info.ModTime(), files
+	return files, nil
+}
@@ -0,0 +1,63 @@
+package ubuntu
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/adelylria/GoFinder/models"
+)
+
+func TestLoadRecentFiles(t *testing.T) {
+	dir := t.TempDir()
+	report := filepath.Join(dir, "my report.pdf")
+	notes := filepath.Join(dir, "notes.txt")
+	for _, path := range []string{report, notes} {
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	xbel := writeDesktopFile(t, dir, "recently-used.xbel", `<?xml version="1.0" encoding="UTF-8"?>
+<xbel version="1.0"
+      xmlns:bookmark="http://www.freedesktop.org/standards/desktop-bookmarks"
+      xmlns:mime="http://www.freedesktop.org/standards/shared-mime-info">
+  <bookmark href="file://`+filepath.ToSlash(dir)+`/my%20report.pdf" added="2024-03-01T09:00:00Z" modified="2024-03-01T09:00:00Z" visited="2024-03-01T09:00:00Z">
+    <info>
+      <metadata owner="http://freedesktop.org">
+        <mime:mime-type type="application/pdf"/>
+        <bookmark:applications>
+          <bookmark:application name="org.gnome.Evince" exec="&apos;evince %u&apos;" modified="2024-03-02T10:00:00Z" count="2"/>
+          <bookmark:application name="Firefox" exec="&apos;firefox %u&apos;" modified="2024-03-01T09:00:00Z" count="1"/>
+        </bookmark:applications>
+      </metadata>
+    </info>
+  </bookmark>
+  <bookmark href="file://`+filepath.ToSlash(dir)+`/notes.txt" modified="2024-03-05T08:00:00Z">
+    <info>
+      <metadata owner="http://freedesktop.org">
+        <mime:mime-type type="text/plain"/>
+        <bookmark:applications>
+          <bookmark:application name="gedit" exec="&apos;gedit %u&apos;" timestamp="1709625600" count="1"/>
+        </bookmark:applications>
+      </metadata>
+    </info>
+  </bookmark>
+  <bookmark href="file://`+filepath.ToSlash(dir)+`/deleted.txt" modified="2024-03-06T08:00:00Z"/>
+  <bookmark href="https://example.com/page" modified="2024-03-06T08:00:00Z"/>
+</xbel>
+`)
+
+	got, err := LoadRecentFiles(xbel)
+	if err != nil {
+		t.Fatalf("LoadRecentFiles: %v", err)
+	}
+	want := []models.RecentFile{
+		{Path: notes, MimeType: "text/plain", AppName: "gedit", Modified: time.Unix(1709625600, 0)},
+		{Path: report, MimeType: "application/pdf", AppName: "org.gnome.Evince", Modified: time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("LoadRecentFiles() = %+v, want %+v", got, want)
+	}
+}
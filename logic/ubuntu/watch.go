@@ -0,0 +1,73 @@
+package ubuntu
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/adelylria/GoFinder/logic/common"
+	"github.com/adelylria/GoFinder/models"
+)
+
+// WatchDirs devuelve los directorios de aplicaciones que hay que vigilar.
+func (f LinuxAppFinder) WatchDirs() []string {
+	return common.GetAppDirs()
+}
+
+// Refresh vuelve a resolver solo los IDs de fichero afectados por paths.
+// Cada ID se busca en todos los directorios por orden de precedencia, de
+// modo que borrar el .desktop del usuario deja ver de nuevo el del sistema.
+func (f LinuxAppFinder) Refresh(paths []string) []models.AppChange {
+	dirs := common.GetAppDirs()
+	icons := NewIconLookup(refreshIconTheme())
+	done := make(map[string]bool)
+	var changes []models.AppChange
+
+	for _, path := range paths {
+		if !strings.HasSuffix(path, ".desktop") {
+			continue
+		}
+		dir, rel, ok := relativeToAppDir(dirs, path)
+		if !ok {
+			continue
+		}
+		id := common.DesktopFileID(dir, path)
+		if done[id] {
+			continue
+		}
+		done[id] = true
+
+		change := models.AppChange{ID: models.DesktopAppID(id)}
+		if app, ok := resolveDesktopID(dirs, rel, id, icons); ok {
+			change.App = &app
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// resolveDesktopID carga el primer fichero con esa ruta relativa, aunque
+// esté oculto: Hidden=true en el directorio del usuario sigue "borrando" la
+// entrada del sistema.
+func resolveDesktopID(dirs []string, rel, id string, icons *IconLookup) (models.Application, bool) {
+	for _, dir := range dirs {
+		path := filepath.Join(dir, rel)
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			continue
+		}
+		return loadDesktopApp(path, id, icons)
+	}
+	return models.Application{}, false
+}
+
+// relativeToAppDir devuelve el directorio de aplicaciones que contiene path
+// y la ruta relativa a él.
+func relativeToAppDir(dirs []string, path string) (string, string, bool) {
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(dir, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return dir, rel, true
+		}
+	}
+	return "", "", false
+}
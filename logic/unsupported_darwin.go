@@ -13,6 +13,32 @@ func LoadAppIcon(app models.Application) fyne.Resource {
 	return nil
 }
 
+func WarmIconCache() {}
+
 func RunApplication(app models.Application) error {
 	return errors.New("darwin is not supported")
 }
+
+func RunCommand(command string) error {
+	return errors.New("darwin is not supported")
+}
+
+func RunCommandInTerminal(command, terminal string) error {
+	return errors.New("darwin is not supported")
+}
+
+func OpenPath(path string) error {
+	return errors.New("darwin is not supported")
+}
+
+func OpenPathWith(app models.Application, path string) error {
+	return errors.New("darwin is not supported")
+}
+
+func RevealPath(path string) error {
+	return errors.New("darwin is not supported")
+}
+
+func RecentFiles() ([]models.RecentFile, error) {
+	return nil, errors.New("darwin is not supported")
+}
@@ -0,0 +1,104 @@
+package logic
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/adelylria/GoFinder/core/logger"
+	"github.com/adelylria/GoFinder/models"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce agrupa la ráfaga de eventos de una instalación (decenas de
+// ficheros en pocos milisegundos) en un único reprocesado.
+const watchDebounce = 500 * time.Millisecond
+
+// IncrementalFinder lo implementan los buscadores capaces de reprocesar
+// solo los ficheros que cambiaron en disco.
+type IncrementalFinder interface {
+	AppFinder
+	WatchDirs() []string
+	Refresh(paths []string) []models.AppChange
+}
+
+// WatchApplications vigila los directorios de descubrimiento y llama a
+// onChange, desde otra goroutine, con las apps afectadas por cada ráfaga de
+// cambios. Devuelve una función para dejar de vigilar.
+func WatchApplications(onChange func([]models.AppChange)) (func(), error) {
+	finder, ok := appFinders[runtime.GOOS].(IncrementalFinder)
+	if !ok {
+		return nil, fmt.Errorf("vigilancia no soportada en %s", runtime.GOOS)
+	}
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range finder.WatchDirs() {
+		watchTree(w, dir)
+	}
+
+	logger.GoSafe(func() { watchLoop(w, finder, onChange) })
+	return func() { w.Close() }, nil
+}
+
+func watchLoop(w *fsnotify.Watcher, finder IncrementalFinder, onChange func([]models.AppChange)) {
+	pending := make(map[string]bool)
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+
+	for {
+		select {
+		case ev, ok := <-w.Events:
+			if !ok {
+				return
+			}
+			pending[ev.Name] = true
+			if ev.Has(fsnotify.Create) {
+				// Los directorios nuevos no se vigilan solos, y los ficheros
+				// que ya traían no generarán eventos.
+				for _, path := range watchTree(w, ev.Name) {
+					pending[path] = true
+				}
+			}
+			timer.Reset(watchDebounce)
+		case err, ok := <-w.Errors:
+			if !ok {
+				return
+			}
+			fmt.Printf("Error vigilando aplicaciones: %v\n", err)
+		case <-timer.C:
+			paths := make([]string, 0, len(pending))
+			for path := range pending {
+				paths = append(paths, path)
+			}
+			pending = make(map[string]bool)
+			if changes := finder.Refresh(paths); len(changes) > 0 {
+				onChange(changes)
+			}
+		}
+	}
+}
+
+// watchTree vigila root y sus subdirectorios (fsnotify no es recursivo) y
+// devuelve los ficheros que contienen.
+func watchTree(w *fsnotify.Watcher, root string) []string {
+	var files []string
+	filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			return nil
+		}
+		if err := w.Add(path); err != nil {
+			fmt.Printf("No se puede vigilar %s: %v\n", path, err)
+		}
+		return nil
+	})
+	return files
+}
@@ -0,0 +1,88 @@
+package logic
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adelylria/GoFinder/models"
+)
+
+type fakeFinder struct {
+	dir string
+
+	mu      sync.Mutex
+	batches [][]string
+}
+
+func (f *fakeFinder) Find(context.Context, func(models.Application)) error { return nil }
+func (f *fakeFinder) WatchDirs() []string                                  { return []string{f.dir} }
+
+func (f *fakeFinder) Refresh(paths []string) []models.AppChange {
+	sort.Strings(paths)
+	f.mu.Lock()
+	f.batches = append(f.batches, paths)
+	f.mu.Unlock()
+	return []models.AppChange{{ID: "x"}}
+}
+
+func TestWatchApplicationsDebounce(t *testing.T) {
+	finder := &fakeFinder{dir: t.TempDir()}
+	prev, had := appFinders[runtime.GOOS]
+	RegisterAppFinder(runtime.GOOS, finder)
+	t.Cleanup(func() {
+		if had {
+			RegisterAppFinder(runtime.GOOS, prev)
+		} else {
+			delete(appFinders, runtime.GOOS)
+		}
+	})
+
+	done := make(chan []models.AppChange, 4)
+	stop, err := WatchApplications(func(c []models.AppChange) { done <- c })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	sub := filepath.Join(finder.dir, "sub")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.desktop", "sub/b.desktop"} {
+		if err := os.WriteFile(filepath.Join(finder.dir, name), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no refresh after changes")
+	}
+	select {
+	case <-done:
+		t.Fatal("burst was not debounced into a single refresh")
+	case <-time.After(2 * watchDebounce):
+	}
+
+	finder.mu.Lock()
+	defer finder.mu.Unlock()
+	got := finder.batches[0]
+	want := map[string]bool{
+		sub:                                    true,
+		filepath.Join(finder.dir, "a.desktop"): true,
+		filepath.Join(sub, "b.desktop"):        true,
+	}
+	for _, path := range got {
+		delete(want, path)
+	}
+	if len(want) != 0 {
+		t.Fatalf("refresh paths = %v, missing %v", got, want)
+	}
+}
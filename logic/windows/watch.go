@@ -0,0 +1,37 @@
+//go:build windows
+// +build windows
+
+package windows
+
+import (
+	"os"
+	"strings"
+
+	"github.com/adelylria/GoFinder/logic/common"
+	"github.com/adelylria/GoFinder/models"
+)
+
+// WatchDirs devuelve los directorios de accesos directos que hay que vigilar.
+func (f WindowsAppFinder) WatchDirs() []string {
+	return common.GetAppDirs()
+}
+
+// Refresh vuelve a procesar solo los accesos directos de paths. Los
+// duplicados por ejecutable solo se descartan dentro de cada lote: un acceso
+// directo nuevo a un ejecutable ya listado puede salir repetido hasta el
+// siguiente escaneo completo.
+func (f WindowsAppFinder) Refresh(paths []string) []models.AppChange {
+	seen := make(map[string]bool)
+	var changes []models.AppChange
+	for _, path := range paths {
+		if !strings.HasSuffix(strings.ToLower(path), ".lnk") {
+			continue
+		}
+		change := models.AppChange{ID: models.ShortcutAppID(path)}
+		if _, err := os.Stat(path); err == nil {
+			change.App = ProcessWindowsShortcut(path, seen)
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
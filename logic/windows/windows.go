@@ -4,6 +4,7 @@
 package windows
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,16 +17,16 @@ import (
 
 type WindowsAppFinder struct{}
 
-func (f WindowsAppFinder) Find() []models.Application {
-	fmt.Println("Buscando aplicaciones en Windows...")
-	return findWindowsApplications()
+// Find recorre los accesos directos en orden, porque el primero de cada
+// ejecutable gana, y entrega cada app según se resuelve.
+func (f WindowsAppFinder) Find(ctx context.Context, found func(models.Application)) error {
+	return findWindowsApplications(ctx, found)
 }
 
 func ProcessWindowsShortcut(path string, seen map[string]bool) *models.Application {
 	app := resolveWindowsShortcut(path)
 
 	if !common.IsValidApp(app) {
-		fmt.Printf("Descartada (no válida): %s -> %s\n", app.Name, app.Exec)
 		return nil
 	}
 
@@ -33,15 +34,14 @@ func ProcessWindowsShortcut(path string, seen map[string]bool) *models.Applicati
 	if global.ExcludedApps[baseExec] || global.ExcludedApps[app.Name] ||
 		strings.Contains(strings.ToLower(app.Name), "uninstall") ||
 		strings.Contains(strings.ToLower(app.Name), "settings") {
-		fmt.Printf("Descartada (excluida): %s -> %s\n", app.Name, app.Exec)
 		return nil
 	}
 
 	if seen[app.Exec] {
-		fmt.Printf("Descartada (duplicada): %s -> %s\n", app.Name, app.Exec)
 		return nil
 	}
 	seen[app.Exec] = true
+	app.AssignStableID()
 
 	iconPath, iconIndex := common.ParseIconLocation(app.Icon)
 	app.IconPath = iconPath
@@ -58,39 +58,39 @@ func ProcessWindowsShortcut(path string, seen map[string]bool) *models.Applicati
 	return &app
 }
 
-func findWindowsApplications() []models.Application {
-	var apps []models.Application
+func findWindowsApplications(ctx context.Context, found func(models.Application)) error {
 	seen := make(map[string]bool)
 	desktopDir := filepath.Join(os.Getenv("USERPROFILE"), "Desktop")
 
 	for _, dir := range common.GetAppDirs() {
-		fmt.Println("Escaneando directorio:", dir)
-		addShortcutsFromDir(&apps, dir, desktopDir, seen)
+		if err := addShortcutsFromDir(ctx, dir, desktopDir, seen, found); err != nil {
+			return err
+		}
 	}
-	return apps
+	return nil
 }
 
-func addShortcutsFromDir(apps *[]models.Application, dir string, desktopDir string, seen map[string]bool) {
+func addShortcutsFromDir(ctx context.Context, dir string, desktopDir string, seen map[string]bool, found func(models.Application)) error {
 	absDir, _ := filepath.Abs(dir)
 	absDesktop, _ := filepath.Abs(desktopDir)
 
 	if absDir == absDesktop {
-		processDesktopDir(apps, dir, seen)
-		return
+		return processDesktopDir(ctx, dir, seen, found)
 	}
 
-	if err := walkAndAddShortcuts(apps, dir, seen); err != nil {
-		fmt.Printf("Error recorriendo %s: %v\n", dir, err)
-	}
+	return walkAndAddShortcuts(ctx, dir, seen, found)
 }
 
-func processDesktopDir(apps *[]models.Application, dir string, seen map[string]bool) {
+func processDesktopDir(ctx context.Context, dir string, seen map[string]bool, found func(models.Application)) error {
 	files, err := os.ReadDir(dir)
 	if err != nil {
 		fmt.Printf("Error leyendo escritorio: %v\n", err)
-		return
+		return nil
 	}
 	for _, file := range files {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		if file.IsDir() {
 			continue
 		}
@@ -98,27 +98,29 @@ func processDesktopDir(apps *[]models.Application, dir string, seen map[string]b
 		if !strings.HasSuffix(name, ".lnk") {
 			continue
 		}
-		addIfShortcutPath(apps, filepath.Join(dir, file.Name()), seen)
+		addIfShortcutPath(filepath.Join(dir, file.Name()), seen, found)
 	}
+	return nil
 }
 
-func walkAndAddShortcuts(apps *[]models.Application, dir string, seen map[string]bool) error {
+func walkAndAddShortcuts(ctx context.Context, dir string, seen map[string]bool, found func(models.Application)) error {
 	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		if err != nil {
-			fmt.Printf("Error accediendo %s: %v\n", path, err)
 			return nil
 		}
 		if info.IsDir() || !strings.HasSuffix(strings.ToLower(path), ".lnk") {
 			return nil
 		}
-		addIfShortcutPath(apps, path, seen)
+		addIfShortcutPath(path, seen, found)
 		return nil
 	})
 }
 
-func addIfShortcutPath(apps *[]models.Application, path string, seen map[string]bool) {
+func addIfShortcutPath(path string, seen map[string]bool, found func(models.Application)) {
 	if app := ProcessWindowsShortcut(path, seen); app != nil {
-		*apps = append(*apps, *app)
-		fmt.Printf("Añadida: %s -> %s\n", app.Name, app.Exec)
+		found(*app)
 	}
 }
@@ -271,6 +271,7 @@ func LoadIconFromHICON(hIcon win.HICON, nameHint string) fyne.Resource {
 // resolveWindowsShortcut resuelve un acceso directo de Windows
 func resolveWindowsShortcut(path string) models.Application {
 	app := models.NewApplication()
+	app.SourcePath = path
 	app.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
 
 	lf, err := lnk.File(path)
@@ -1,16 +1,51 @@
 package models
 
 import (
+	"path/filepath"
+	"runtime"
+	"strings"
+
 	"github.com/google/uuid"
 )
 
 type Application struct {
-	ID       string // UUID único
+	ID       string // estable entre escaneos (ver AssignStableID); UUID si no hay origen
 	Name     string
 	Exec     string
 	Icon     string
 	IconPath string
 	IconIdx  int
+
+	SourcePath string // fichero del que se descubrió (.desktop o .lnk)
+	DesktopID  string // ID de fichero .desktop según la especificación (Linux)
+
+	// Metadatos de la especificación Desktop Entry (solo Linux).
+	Type             string
+	UntranslatedName string // Name sin traducir, para buscar también en inglés
+	GenericName      string
+	Comment          string
+	Keywords         []string
+	Categories       []string
+	NoDisplay        bool
+	Hidden           bool
+	OnlyShowIn       []string
+	NotShowIn        []string
+	TryExec          string
+	Terminal         bool
+	Path             string
+	StartupWMClass   string
+	Actions          []string        // IDs de la clave Actions, tal cual
+	DesktopActions   []DesktopAction // las de Actions que tienen sección válida
+}
+
+// DesktopAction es una sección [Desktop Action ID] de un .desktop, como la
+// "Nueva ventana privada" de Firefox.
+type DesktopAction struct {
+	ID       string
+	Name     string
+	Exec     string
+	Icon     string
+	IconPath string
 }
 
 func NewApplication() Application {
@@ -18,3 +53,58 @@ func NewApplication() Application {
 		ID: uuid.New().String(),
 	}
 }
+
+// AssignStableID sustituye el UUID por un ID derivado del origen, para que
+// la misma app conserve su ID entre arranques y reescaneos: el ID de fichero
+// .desktop en Linux y, en Windows, el acceso directo o el ejecutable. Si no
+// hay origen conocido se mantiene el UUID.
+func (a *Application) AssignStableID() {
+	switch {
+	case a.DesktopID != "":
+		a.ID = DesktopAppID(a.DesktopID)
+	case a.SourcePath != "":
+		a.ID = ShortcutAppID(a.SourcePath)
+	case a.Exec != "":
+		a.ID = "exe:" + normalizePath(a.Exec)
+	}
+}
+
+// ForAction devuelve la app que lanza action: la misma entrada con el Exec
+// y el icono de la acción, y un ID propio para su icono.
+func (a Application) ForAction(action DesktopAction) Application {
+	app := a
+	app.ID = a.ID + "#" + action.ID
+	app.Exec = action.Exec
+	if action.Icon != "" {
+		app.Icon, app.IconPath = action.Icon, action.IconPath
+	}
+	app.DesktopActions = nil
+	return app
+}
+
+// DesktopAppID es el ID de la app descubierta con ese ID de fichero .desktop.
+func DesktopAppID(desktopID string) string {
+	return "desktop:" + desktopID
+}
+
+// ShortcutAppID es el ID de la app descubierta en ese acceso directo.
+func ShortcutAppID(path string) string {
+	return "lnk:" + normalizePath(path)
+}
+
+// AppChange describe una app añadida, modificada (App != nil) o eliminada
+// (App == nil) tras un cambio en disco.
+type AppChange struct {
+	ID  string
+	App *Application
+}
+
+// normalizePath limpia la ruta y, en Windows, ignora mayúsculas, que el
+// sistema de ficheros no distingue.
+func normalizePath(path string) string {
+	path = filepath.Clean(path)
+	if runtime.GOOS == "windows" {
+		path = strings.ToLower(path)
+	}
+	return path
+}
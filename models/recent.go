@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// RecentFile es un documento abierto hace poco, según el registro de
+// documentos recientes del escritorio.
+type RecentFile struct {
+	Path     string
+	MimeType string
+	// AppName es la app que lo abrió por última vez, tal como la registra el
+	// escritorio: normalmente su ID de fichero .desktop sin la extensión o su
+	// nombre visible.
+	AppName  string
+	Modified time.Time // cuándo lo abrió AppName
+}